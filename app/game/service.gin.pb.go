@@ -22,6 +22,10 @@ func NewGameServiceError(code int, format string, a ...interface{}) error {
 
 type GameServiceHTTPServer interface {
 	Delete(ctx context.Context, request *types.DeleteRequest) (response *types.DeleteResponse, err error)
+
+	ReloadConfig(ctx context.Context, request *types.ReloadConfigRequest) (response *types.ReloadConfigResponse, err error)
+
+	Players(ctx context.Context, request *types.PlayersRequest) (response *types.PlayersResponse, err error)
 }
 
 func RegisterGameServiceHTTPServer(r gin.IRouter, srv GameServiceHTTPServer) {
@@ -65,10 +69,68 @@ func (s *_GameService) Delete_0(ctx *gin.Context) {
 	_GameServiceSuccess(ctx, out)
 }
 
+func (s *_GameService) ReloadConfig_0(ctx *gin.Context) {
+	var in types.ReloadConfigRequest
+
+	if err := ctx.ShouldBindUri(&in); err != nil {
+		_GameServiceParamsError(ctx, err)
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&in); err != nil {
+		_GameServiceParamsError(ctx, err)
+		return
+	}
+
+	md := metadata.New(nil)
+	for k, v := range ctx.Request.Header {
+		md.Set(k, v...)
+	}
+	newCtx := metadata.NewIncomingContext(ctx, md)
+	out, err := s.server.(GameServiceHTTPServer).ReloadConfig(newCtx, &in)
+	if err != nil {
+		_GameServiceError(ctx, err)
+		return
+	}
+
+	_GameServiceSuccess(ctx, out)
+}
+
+func (s *_GameService) Players_0(ctx *gin.Context) {
+	var in types.PlayersRequest
+
+	if err := ctx.ShouldBindUri(&in); err != nil {
+		_GameServiceParamsError(ctx, err)
+		return
+	}
+
+	if err := ctx.ShouldBindQuery(&in); err != nil {
+		_GameServiceParamsError(ctx, err)
+		return
+	}
+
+	md := metadata.New(nil)
+	for k, v := range ctx.Request.Header {
+		md.Set(k, v...)
+	}
+	newCtx := metadata.NewIncomingContext(ctx, md)
+	out, err := s.server.(GameServiceHTTPServer).Players(newCtx, &in)
+	if err != nil {
+		_GameServiceError(ctx, err)
+		return
+	}
+
+	_GameServiceSuccess(ctx, out)
+}
+
 func (s *_GameService) _RegisterService() {
 
 	s.router.Handle("DELETE", "/api/v1/delete/:gameID", s.Delete_0)
 
+	s.router.Handle("PUT", "/api/v1/reload/:gameID", s.ReloadConfig_0)
+
+	s.router.Handle("GET", "/api/v1/players/:gameID", s.Players_0)
+
 }
 
 func _GameServiceError(ctx *gin.Context, err error) {
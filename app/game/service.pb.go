@@ -30,24 +30,29 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 func init() { proto.RegisterFile("app/game/service.proto", fileDescriptor_0d2fae7c4c9e5fd8) }
 
 var fileDescriptor_0d2fae7c4c9e5fd8 = []byte{
-	// 261 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x12, 0x4b, 0x2c, 0x28, 0xd0,
-	0x4f, 0x4f, 0xcc, 0x4d, 0xd5, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0xd5, 0x2b, 0x28, 0xca,
-	0x2f, 0xc9, 0x17, 0xe2, 0xcb, 0x2e, 0x4d, 0x4a, 0x05, 0x89, 0x17, 0xc7, 0x83, 0x48, 0x29, 0x29,
-	0xb8, 0xba, 0x92, 0xca, 0x82, 0xd4, 0x62, 0x08, 0x09, 0x51, 0x2b, 0xa5, 0x02, 0x92, 0xcb, 0xc9,
-	0x4c, 0xd2, 0x4f, 0xcf, 0xcf, 0x4f, 0xcf, 0x49, 0xd5, 0x4f, 0x2c, 0xc8, 0xd4, 0x4f, 0xcc, 0xcb,
-	0xcb, 0x2f, 0x49, 0x2c, 0xc9, 0xcc, 0xcf, 0x83, 0xa9, 0x52, 0x40, 0xa8, 0x4a, 0xcf, 0xd7, 0x07,
-	0x8b, 0x25, 0x95, 0xa6, 0x81, 0x79, 0x10, 0x15, 0x46, 0x95, 0x5c, 0xdc, 0xee, 0x89, 0xb9, 0xa9,
-	0xc1, 0x10, 0x87, 0x08, 0x65, 0x71, 0xb1, 0xb9, 0xa4, 0xe6, 0xa4, 0x96, 0xa4, 0x0a, 0xc9, 0xe9,
-	0x21, 0x5c, 0x03, 0xb1, 0x18, 0x22, 0x11, 0x94, 0x5a, 0x58, 0x9a, 0x5a, 0x5c, 0x22, 0x25, 0x8f,
-	0x53, 0xbe, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0x55, 0x49, 0xbe, 0xe9, 0xf2, 0x93, 0xc9, 0x4c, 0x92,
-	0x5a, 0xe2, 0x60, 0xb7, 0x95, 0x19, 0xea, 0xa7, 0x80, 0xe5, 0xf5, 0xab, 0x41, 0x7a, 0x3c, 0x5d,
-	0x6a, 0x9d, 0x82, 0x4e, 0x3c, 0x94, 0x63, 0x78, 0xf0, 0x50, 0x8e, 0xf1, 0xc3, 0x43, 0x39, 0xc6,
-	0x15, 0x8f, 0xe4, 0x18, 0x4f, 0x3c, 0x92, 0x63, 0xbc, 0xf0, 0x48, 0x8e, 0xf1, 0xc1, 0x23, 0x39,
-	0xc6, 0x19, 0x8f, 0xe5, 0x18, 0xa2, 0x0c, 0xd2, 0x33, 0x4b, 0x32, 0x4a, 0x93, 0xf4, 0x92, 0xf3,
-	0x73, 0xf5, 0xe1, 0xb6, 0x21, 0x58, 0xba, 0xf9, 0x05, 0xa9, 0x45, 0x89, 0x25, 0xf9, 0x45, 0xfa,
-	0xb0, 0x80, 0x4a, 0x62, 0x03, 0xfb, 0xca, 0x18, 0x10, 0x00, 0x00, 0xff, 0xff, 0xe7, 0xd9, 0xf5,
-	0xa4, 0x63, 0x01, 0x00, 0x00,
+	// 340 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0x4d, 0x4b, 0xc3, 0x30,
+	0x18, 0xc7, 0x97, 0x1d, 0x26, 0x54, 0xf1, 0x90, 0x83, 0x2f, 0x45, 0xb2, 0x31, 0xe6, 0x65, 0xe0,
+	0xe2, 0xcb, 0xcd, 0xa3, 0x0e, 0xc4, 0x9b, 0xcc, 0x9b, 0x17, 0x49, 0xb7, 0x67, 0xb5, 0xda, 0xf5,
+	0x89, 0x4d, 0x3a, 0x18, 0x22, 0x82, 0x5f, 0xc1, 0x8b, 0x1f, 0xc1, 0x8f, 0xb2, 0xa3, 0xe0, 0x17,
+	0xd8, 0xaa, 0x27, 0x4f, 0x7e, 0x04, 0x69, 0xb2, 0x76, 0xc2, 0x18, 0x5e, 0x1e, 0xda, 0xe7, 0xff,
+	0x4b, 0x7e, 0x4f, 0x48, 0x9c, 0x0d, 0x21, 0x25, 0xf7, 0xc5, 0x00, 0xb8, 0x82, 0x78, 0x18, 0x74,
+	0xa1, 0x25, 0x63, 0xd4, 0x48, 0xd7, 0xef, 0x12, 0x0f, 0xb2, 0xbe, 0xba, 0xce, 0xaa, 0xeb, 0x16,
+	0x9c, 0x1e, 0x49, 0x50, 0xb6, 0x5a, 0xd6, 0x6d, 0x64, 0x59, 0x18, 0x78, 0xdc, 0x47, 0xf4, 0x43,
+	0xe0, 0x42, 0x06, 0x5c, 0x44, 0x11, 0x6a, 0xa1, 0x03, 0x8c, 0x72, 0xaa, 0x36, 0xa7, 0x7c, 0xe4,
+	0xa6, 0xe7, 0x25, 0x7d, 0xf3, 0x67, 0x89, 0xc3, 0xef, 0xb2, 0xb3, 0x7a, 0x26, 0x06, 0x70, 0x69,
+	0x27, 0xa1, 0xb7, 0x4e, 0xa5, 0x0d, 0x21, 0x68, 0xa0, 0xac, 0x35, 0x1f, 0xc7, 0x9a, 0x6d, 0xd0,
+	0x81, 0xfb, 0x04, 0x94, 0x76, 0xab, 0x4b, 0x73, 0x25, 0x31, 0x52, 0x50, 0xaf, 0x3e, 0x7f, 0x7c,
+	0xbd, 0x94, 0xb7, 0x9b, 0x9b, 0x66, 0xb8, 0xe1, 0x01, 0xef, 0x99, 0x9c, 0x3f, 0x64, 0x6b, 0xce,
+	0xdb, 0x8f, 0xf4, 0xc9, 0x59, 0xeb, 0x40, 0x88, 0xa2, 0x77, 0x8a, 0x51, 0x3f, 0xf0, 0x69, 0x63,
+	0x61, 0xc7, 0xbf, 0x71, 0xee, 0xdd, 0xfd, 0x87, 0x9a, 0xd9, 0xeb, 0xc6, 0xbe, 0xe3, 0x16, 0xf6,
+	0xd8, 0x50, 0x85, 0xfd, 0x98, 0x34, 0x69, 0xe4, 0xac, 0x5c, 0x84, 0x62, 0x04, 0xb1, 0xa2, 0x8b,
+	0xa7, 0x99, 0x25, 0xb9, 0xb6, 0xb6, 0x1c, 0x98, 0x19, 0x6b, 0xc6, 0xe8, 0xd2, 0xad, 0xdc, 0x28,
+	0x2d, 0x50, 0x28, 0x4f, 0x3a, 0xe3, 0x29, 0x2b, 0x4d, 0xa6, 0x8c, 0xfc, 0x4c, 0x19, 0x79, 0x4b,
+	0x19, 0x19, 0xa7, 0x8c, 0xbc, 0xa7, 0x8c, 0x4c, 0x52, 0x46, 0x5e, 0x3f, 0x59, 0xe9, 0x6a, 0xdf,
+	0x0f, 0xf4, 0x4d, 0xe2, 0xb5, 0xba, 0x38, 0xe0, 0x85, 0x6f, 0xfe, 0xb5, 0x87, 0x12, 0x62, 0xa1,
+	0x31, 0xe6, 0xf9, 0xd3, 0xf0, 0x2a, 0xe6, 0x1e, 0x8f, 0x7e, 0x07, 0x00, 0x9f, 0x96, 0x6e, 0xed,
+	0x55, 0x02, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -64,6 +69,10 @@ const _ = grpc.SupportPackageIsVersion4
 type GameServiceClient interface {
 	//delete game
 	Delete(ctx context.Context, in *types.DeleteRequest, opts ...grpc.CallOption) (*types.DeleteResponse, error)
+	//reload game config
+	ReloadConfig(ctx context.Context, in *types.ReloadConfigRequest, opts ...grpc.CallOption) (*types.ReloadConfigResponse, error)
+	//game players
+	Players(ctx context.Context, in *types.PlayersRequest, opts ...grpc.CallOption) (*types.PlayersResponse, error)
 }
 
 type gameServiceClient struct {
@@ -83,10 +92,32 @@ func (c *gameServiceClient) Delete(ctx context.Context, in *types.DeleteRequest,
 	return out, nil
 }
 
+func (c *gameServiceClient) ReloadConfig(ctx context.Context, in *types.ReloadConfigRequest, opts ...grpc.CallOption) (*types.ReloadConfigResponse, error) {
+	out := new(types.ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, "/kubegames_game.GameService/ReloadConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) Players(ctx context.Context, in *types.PlayersRequest, opts ...grpc.CallOption) (*types.PlayersResponse, error) {
+	out := new(types.PlayersResponse)
+	err := c.cc.Invoke(ctx, "/kubegames_game.GameService/Players", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServiceServer is the server API for GameService service.
 type GameServiceServer interface {
 	//delete game
 	Delete(context.Context, *types.DeleteRequest) (*types.DeleteResponse, error)
+	//reload game config
+	ReloadConfig(context.Context, *types.ReloadConfigRequest) (*types.ReloadConfigResponse, error)
+	//game players
+	Players(context.Context, *types.PlayersRequest) (*types.PlayersResponse, error)
 }
 
 // UnimplementedGameServiceServer can be embedded to have forward compatible implementations.
@@ -96,6 +127,12 @@ type UnimplementedGameServiceServer struct {
 func (*UnimplementedGameServiceServer) Delete(ctx context.Context, req *types.DeleteRequest) (*types.DeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (*UnimplementedGameServiceServer) ReloadConfig(ctx context.Context, req *types.ReloadConfigRequest) (*types.ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (*UnimplementedGameServiceServer) Players(ctx context.Context, req *types.PlayersRequest) (*types.PlayersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Players not implemented")
+}
 
 func RegisterGameServiceServer(s *grpc.Server, srv GameServiceServer) {
 	s.RegisterService(&_GameService_serviceDesc, srv)
@@ -119,6 +156,42 @@ func _GameService_Delete_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubegames_game.GameService/ReloadConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ReloadConfig(ctx, req.(*types.ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_Players_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(types.PlayersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).Players(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/kubegames_game.GameService/Players",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).Players(ctx, req.(*types.PlayersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _GameService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "kubegames_game.GameService",
 	HandlerType: (*GameServiceServer)(nil),
@@ -127,6 +200,14 @@ var _GameService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _GameService_Delete_Handler,
 		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _GameService_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "Players",
+			Handler:    _GameService_Players_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "app/game/service.proto",
@@ -108,31 +108,208 @@ func (m *DeleteResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_DeleteResponse proto.InternalMessageInfo
 
+type ReloadConfigRequest struct {
+	//game id
+	GameID string `protobuf:"bytes,1,opt,name=gameID,proto3" json:"gameID,omitempty" uri:"gameID" binding:"required"`
+	//game config
+	Config               string   `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" xorm:"-" gorm:"-"`
+	XXX_unrecognized     []byte   `json:"-" xorm:"-" gorm:"-"`
+	XXX_sizecache        int32    `json:"-" xorm:"-" gorm:"-"`
+}
+
+func (m *ReloadConfigRequest) Reset()         { *m = ReloadConfigRequest{} }
+func (m *ReloadConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*ReloadConfigRequest) ProtoMessage()    {}
+func (*ReloadConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6e3feef393c80004, []int{2}
+}
+func (m *ReloadConfigRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReloadConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReloadConfigRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReloadConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadConfigRequest.Merge(m, src)
+}
+func (m *ReloadConfigRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReloadConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadConfigRequest proto.InternalMessageInfo
+
+type ReloadConfigResponse struct {
+	//success
+	Success              bool     `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" xorm:"-" gorm:"-"`
+	XXX_unrecognized     []byte   `json:"-" xorm:"-" gorm:"-"`
+	XXX_sizecache        int32    `json:"-" xorm:"-" gorm:"-"`
+}
+
+func (m *ReloadConfigResponse) Reset()         { *m = ReloadConfigResponse{} }
+func (m *ReloadConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*ReloadConfigResponse) ProtoMessage()    {}
+func (*ReloadConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6e3feef393c80004, []int{3}
+}
+func (m *ReloadConfigResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ReloadConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ReloadConfigResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ReloadConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadConfigResponse.Merge(m, src)
+}
+func (m *ReloadConfigResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *ReloadConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadConfigResponse proto.InternalMessageInfo
+
+type PlayersRequest struct {
+	//game id
+	GameID               string   `protobuf:"bytes,1,opt,name=gameID,proto3" json:"gameID,omitempty" uri:"gameID" binding:"required"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" xorm:"-" gorm:"-"`
+	XXX_unrecognized     []byte   `json:"-" xorm:"-" gorm:"-"`
+	XXX_sizecache        int32    `json:"-" xorm:"-" gorm:"-"`
+}
+
+func (m *PlayersRequest) Reset()         { *m = PlayersRequest{} }
+func (m *PlayersRequest) String() string { return proto.CompactTextString(m) }
+func (*PlayersRequest) ProtoMessage()    {}
+func (*PlayersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6e3feef393c80004, []int{4}
+}
+func (m *PlayersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PlayersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PlayersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PlayersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayersRequest.Merge(m, src)
+}
+func (m *PlayersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *PlayersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayersRequest proto.InternalMessageInfo
+
+type PlayersResponse struct {
+	//online players
+	Players uint32 `protobuf:"varint,1,opt,name=players,proto3" json:"players,omitempty"`
+	//maximum players
+	Capacity             uint32   `protobuf:"varint,2,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-" xorm:"-" gorm:"-"`
+	XXX_unrecognized     []byte   `json:"-" xorm:"-" gorm:"-"`
+	XXX_sizecache        int32    `json:"-" xorm:"-" gorm:"-"`
+}
+
+func (m *PlayersResponse) Reset()         { *m = PlayersResponse{} }
+func (m *PlayersResponse) String() string { return proto.CompactTextString(m) }
+func (*PlayersResponse) ProtoMessage()    {}
+func (*PlayersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6e3feef393c80004, []int{5}
+}
+func (m *PlayersResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PlayersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PlayersResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PlayersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayersResponse.Merge(m, src)
+}
+func (m *PlayersResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *PlayersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayersResponse proto.InternalMessageInfo
+
 func init() {
 	proto.RegisterType((*DeleteRequest)(nil), "kubegames_types.DeleteRequest")
 	proto.RegisterType((*DeleteResponse)(nil), "kubegames_types.DeleteResponse")
+	proto.RegisterType((*ReloadConfigRequest)(nil), "kubegames_types.ReloadConfigRequest")
+	proto.RegisterType((*ReloadConfigResponse)(nil), "kubegames_types.ReloadConfigResponse")
+	proto.RegisterType((*PlayersRequest)(nil), "kubegames_types.PlayersRequest")
+	proto.RegisterType((*PlayersResponse)(nil), "kubegames_types.PlayersResponse")
 }
 
 func init() { proto.RegisterFile("app/game/types/types.proto", fileDescriptor_6e3feef393c80004) }
 
 var fileDescriptor_6e3feef393c80004 = []byte{
-	// 248 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4a, 0x2c, 0x28, 0xd0,
-	0x4f, 0x4f, 0xcc, 0x4d, 0xd5, 0x2f, 0xa9, 0x2c, 0x48, 0x2d, 0x86, 0x90, 0x7a, 0x05, 0x45, 0xf9,
-	0x25, 0xf9, 0x42, 0xfc, 0xd9, 0xa5, 0x49, 0xa9, 0x20, 0xb9, 0xe2, 0x78, 0xb0, 0xb0, 0x94, 0x02,
-	0x48, 0x71, 0x4e, 0x66, 0x92, 0x7e, 0x7a, 0x7e, 0x7a, 0xbe, 0x3e, 0x58, 0x51, 0x52, 0x69, 0x1a,
-	0x98, 0x07, 0xd1, 0xa2, 0xe4, 0xc3, 0xc5, 0xeb, 0x92, 0x9a, 0x93, 0x5a, 0x92, 0x1a, 0x94, 0x5a,
-	0x58, 0x9a, 0x5a, 0x5c, 0x22, 0x64, 0xcd, 0xc5, 0x06, 0x32, 0xc1, 0xd3, 0x45, 0x82, 0x51, 0x81,
-	0x51, 0x83, 0xd3, 0x49, 0xf9, 0xd3, 0x3d, 0x79, 0xf9, 0xd2, 0xa2, 0x4c, 0x2b, 0x25, 0x88, 0xb0,
-	0x92, 0x42, 0x52, 0x66, 0x5e, 0x4a, 0x66, 0x5e, 0xba, 0x95, 0x52, 0x51, 0x6a, 0x61, 0x69, 0x66,
-	0x51, 0x6a, 0x8a, 0x52, 0x10, 0x54, 0x8b, 0x92, 0x16, 0x17, 0x1f, 0xcc, 0xb4, 0xe2, 0x82, 0xfc,
-	0xbc, 0xe2, 0x54, 0x21, 0x09, 0x2e, 0xf6, 0xe2, 0xd2, 0xe4, 0xe4, 0xd4, 0xe2, 0x62, 0xb0, 0x79,
-	0x1c, 0x41, 0x30, 0xae, 0x53, 0xc4, 0x89, 0x87, 0x72, 0x0c, 0x0f, 0x1e, 0xca, 0x31, 0x7e, 0x78,
-	0x28, 0xc7, 0xb8, 0xe2, 0x91, 0x1c, 0xe3, 0x89, 0x47, 0x72, 0x8c, 0x17, 0x1e, 0xc9, 0x31, 0x3e,
-	0x78, 0x24, 0xc7, 0x38, 0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x59, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92,
-	0x5e, 0x72, 0x7e, 0xae, 0x3e, 0xdc, 0x67, 0x08, 0x96, 0x6e, 0x7e, 0x41, 0x6a, 0x51, 0x62, 0x49,
-	0x7e, 0x91, 0x3e, 0x6a, 0x90, 0x24, 0xb1, 0x81, 0xbd, 0x66, 0x0c, 0x08, 0x00, 0x00, 0xff, 0xff,
-	0x7e, 0xc4, 0x57, 0x50, 0x2b, 0x01, 0x00, 0x00,
+	// 335 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x92, 0xcf, 0x4a, 0x2b, 0x31,
+	0x14, 0xc6, 0x9b, 0xbb, 0xe8, 0xed, 0x0d, 0xb4, 0x85, 0xb9, 0x97, 0x4b, 0xe9, 0x22, 0x2d, 0x71,
+	0x23, 0x82, 0x1d, 0x41, 0x70, 0x51, 0x77, 0xb5, 0x20, 0x82, 0x82, 0x64, 0x25, 0x6e, 0x24, 0x33,
+	0x3d, 0x1d, 0xa3, 0xd3, 0x49, 0x9a, 0x3f, 0x8b, 0xbe, 0x89, 0x8f, 0xe0, 0xa3, 0x74, 0xe9, 0x13,
+	0x48, 0x3b, 0xbe, 0x80, 0xf8, 0x04, 0x32, 0x99, 0xfe, 0xa1, 0x2b, 0x37, 0xdd, 0x84, 0x7c, 0x27,
+	0xe7, 0xfc, 0xbe, 0x24, 0x7c, 0xb8, 0xcd, 0x95, 0x0a, 0x13, 0x3e, 0x81, 0xd0, 0xce, 0x14, 0x98,
+	0x72, 0xed, 0x29, 0x2d, 0xad, 0x0c, 0x9a, 0xcf, 0x2e, 0x82, 0xe2, 0xcc, 0x3c, 0xf8, 0x72, 0xbb,
+	0x5b, 0x34, 0xa7, 0x22, 0x0a, 0x13, 0x99, 0xc8, 0xd0, 0x37, 0x45, 0x6e, 0xec, 0x55, 0x39, 0x42,
+	0xaf, 0x71, 0x7d, 0x08, 0x29, 0x58, 0x60, 0x30, 0x75, 0x60, 0x6c, 0x70, 0x8e, 0xab, 0x05, 0xe1,
+	0x6a, 0xd8, 0x42, 0x5d, 0x74, 0xf8, 0x67, 0x70, 0xf0, 0xf5, 0xde, 0xe9, 0x38, 0x2d, 0xfa, 0xb4,
+	0x2c, 0xd3, 0x6e, 0x24, 0xb2, 0x91, 0xc8, 0x92, 0x3e, 0xd5, 0x30, 0x75, 0x42, 0xc3, 0x88, 0xb2,
+	0xd5, 0x08, 0x3d, 0xc2, 0x8d, 0x35, 0xcd, 0x28, 0x99, 0x19, 0x08, 0x5a, 0xf8, 0xb7, 0x71, 0x71,
+	0x0c, 0xc6, 0x78, 0x5e, 0x8d, 0xad, 0x25, 0x7d, 0xc2, 0x7f, 0x19, 0xa4, 0x92, 0x8f, 0x2e, 0x64,
+	0x36, 0x16, 0xc9, 0x3e, 0xfc, 0x83, 0xff, 0xb8, 0x1a, 0x7b, 0x5a, 0xeb, 0x57, 0x31, 0xcc, 0x56,
+	0x8a, 0x9e, 0xe0, 0x7f, 0xbb, 0x5e, 0x3f, 0xde, 0xee, 0x06, 0x37, 0x6e, 0x53, 0x3e, 0x03, 0x6d,
+	0xf6, 0xf2, 0x31, 0x97, 0xb8, 0xb9, 0xc1, 0x6d, 0xbd, 0x55, 0x59, 0xf2, 0xc0, 0x3a, 0x5b, 0xcb,
+	0xa0, 0x8d, 0x6b, 0x31, 0x57, 0x3c, 0x16, 0x76, 0xe6, 0xdf, 0x51, 0x67, 0x1b, 0x3d, 0xb8, 0x9b,
+	0x2f, 0x49, 0x65, 0xb1, 0x24, 0xe8, 0x73, 0x49, 0xd0, 0x6b, 0x4e, 0xd0, 0x3c, 0x27, 0xe8, 0x2d,
+	0x27, 0x68, 0x91, 0x13, 0xf4, 0xf2, 0x41, 0x2a, 0xf7, 0x67, 0x89, 0xb0, 0x8f, 0x2e, 0xea, 0xc5,
+	0x72, 0x12, 0x6e, 0xf2, 0xb0, 0xdd, 0x1d, 0x4b, 0x05, 0x9a, 0x5b, 0xa9, 0xc3, 0xdd, 0x20, 0x45,
+	0x55, 0x1f, 0x88, 0xd3, 0xef, 0x01, 0x00, 0x7b, 0x7e, 0x69, 0xe7, 0x61, 0x02, 0x00, 0x00,
 }
 
 func (this *DeleteRequest) VerboseEqual(that interface{}) error {
@@ -255,112 +432,567 @@ func (this *DeleteResponse) Equal(that interface{}) bool {
 	}
 	return true
 }
-func (this *DeleteRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ReloadConfigRequest) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&types.DeleteRequest{")
-	s = append(s, "GameID: "+fmt.Sprintf("%#v", this.GameID)+",\n")
-	if this.XXX_unrecognized != nil {
-		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+
+	that1, ok := that.(*ReloadConfigRequest)
+	if !ok {
+		that2, ok := that.(ReloadConfigRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *ReloadConfigRequest")
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *DeleteResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *ReloadConfigRequest but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *ReloadConfigRequest but is not nil && this == nil")
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&types.DeleteResponse{")
-	s = append(s, "Success: "+fmt.Sprintf("%#v", this.Success)+",\n")
-	if this.XXX_unrecognized != nil {
-		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	if this.GameID != that1.GameID {
+		return fmt.Errorf("GameID this(%v) Not Equal that(%v)", this.GameID, that1.GameID)
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func valueToGoStringTypes(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if this.Config != that1.Config {
+		return fmt.Errorf("Config this(%v) Not Equal that(%v)", this.Config, that1.Config)
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-func (m *DeleteRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
 	}
-	return dAtA[:n], nil
-}
-
-func (m *DeleteRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return nil
 }
+func (this *ReloadConfigRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *DeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+	that1, ok := that.(*ReloadConfigRequest)
+	if !ok {
+		that2, ok := that.(ReloadConfigRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.GameID) > 0 {
-		i -= len(m.GameID)
-		copy(dAtA[i:], m.GameID)
-		i = encodeVarintTypes(dAtA, i, uint64(len(m.GameID)))
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *DeleteResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.GameID != that1.GameID {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *DeleteResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.Config != that1.Config {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
 }
-
-func (m *DeleteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.XXX_unrecognized != nil {
-		i -= len(m.XXX_unrecognized)
-		copy(dAtA[i:], m.XXX_unrecognized)
+func (this *ReloadConfigResponse) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
 	}
-	if m.Success {
-		i--
-		if m.Success {
-			dAtA[i] = 1
+
+	that1, ok := that.(*ReloadConfigResponse)
+	if !ok {
+		that2, ok := that.(ReloadConfigResponse)
+		if ok {
+			that1 = &that2
 		} else {
-			dAtA[i] = 0
+			return fmt.Errorf("that is not of type *ReloadConfigResponse")
 		}
-		i--
-		dAtA[i] = 0x8
 	}
-	return len(dAtA) - i, nil
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *ReloadConfigResponse but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *ReloadConfigResponse but is not nil && this == nil")
+	}
+	if this.Success != that1.Success {
+		return fmt.Errorf("Success this(%v) Not Equal that(%v)", this.Success, that1.Success)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
 }
+func (this *ReloadConfigResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
+	that1, ok := that.(*ReloadConfigResponse)
+	if !ok {
+		that2, ok := that.(ReloadConfigResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Success != that1.Success {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *PlayersRequest) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*PlayersRequest)
+	if !ok {
+		that2, ok := that.(PlayersRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *PlayersRequest")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *PlayersRequest but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *PlayersRequest but is not nil && this == nil")
+	}
+	if this.GameID != that1.GameID {
+		return fmt.Errorf("GameID this(%v) Not Equal that(%v)", this.GameID, that1.GameID)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *PlayersRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PlayersRequest)
+	if !ok {
+		that2, ok := that.(PlayersRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.GameID != that1.GameID {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *PlayersResponse) VerboseEqual(that interface{}) error {
+	if that == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that == nil && this != nil")
+	}
+
+	that1, ok := that.(*PlayersResponse)
+	if !ok {
+		that2, ok := that.(PlayersResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return fmt.Errorf("that is not of type *PlayersResponse")
+		}
+	}
+	if that1 == nil {
+		if this == nil {
+			return nil
+		}
+		return fmt.Errorf("that is type *PlayersResponse but is nil && this != nil")
+	} else if this == nil {
+		return fmt.Errorf("that is type *PlayersResponse but is not nil && this == nil")
+	}
+	if this.Players != that1.Players {
+		return fmt.Errorf("Players this(%v) Not Equal that(%v)", this.Players, that1.Players)
+	}
+	if this.Capacity != that1.Capacity {
+		return fmt.Errorf("Capacity this(%v) Not Equal that(%v)", this.Capacity, that1.Capacity)
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return fmt.Errorf("XXX_unrecognized this(%v) Not Equal that(%v)", this.XXX_unrecognized, that1.XXX_unrecognized)
+	}
+	return nil
+}
+func (this *PlayersResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*PlayersResponse)
+	if !ok {
+		that2, ok := that.(PlayersResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Players != that1.Players {
+		return false
+	}
+	if this.Capacity != that1.Capacity {
+		return false
+	}
+	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
+		return false
+	}
+	return true
+}
+func (this *DeleteRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.DeleteRequest{")
+	s = append(s, "GameID: "+fmt.Sprintf("%#v", this.GameID)+",\n")
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *DeleteResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.DeleteResponse{")
+	s = append(s, "Success: "+fmt.Sprintf("%#v", this.Success)+",\n")
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReloadConfigRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.ReloadConfigRequest{")
+	s = append(s, "GameID: "+fmt.Sprintf("%#v", this.GameID)+",\n")
+	s = append(s, "Config: "+fmt.Sprintf("%#v", this.Config)+",\n")
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReloadConfigResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.ReloadConfigResponse{")
+	s = append(s, "Success: "+fmt.Sprintf("%#v", this.Success)+",\n")
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PlayersRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&types.PlayersRequest{")
+	s = append(s, "GameID: "+fmt.Sprintf("%#v", this.GameID)+",\n")
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *PlayersResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&types.PlayersResponse{")
+	s = append(s, "Players: "+fmt.Sprintf("%#v", this.Players)+",\n")
+	s = append(s, "Capacity: "+fmt.Sprintf("%#v", this.Capacity)+",\n")
+	if this.XXX_unrecognized != nil {
+		s = append(s, "XXX_unrecognized:"+fmt.Sprintf("%#v", this.XXX_unrecognized)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringTypes(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
+}
+func (m *DeleteRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.GameID) > 0 {
+		i -= len(m.GameID)
+		copy(dAtA[i:], m.GameID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.GameID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DeleteResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DeleteResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DeleteResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Success {
+		i--
+		if m.Success {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReloadConfigRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReloadConfigRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReloadConfigRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Config) > 0 {
+		i -= len(m.Config)
+		copy(dAtA[i:], m.Config)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Config)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.GameID) > 0 {
+		i -= len(m.GameID)
+		copy(dAtA[i:], m.GameID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.GameID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReloadConfigResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReloadConfigResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReloadConfigResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Success {
+		i--
+		if m.Success {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PlayersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlayersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PlayersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.GameID) > 0 {
+		i -= len(m.GameID)
+		copy(dAtA[i:], m.GameID)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.GameID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PlayersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PlayersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PlayersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Capacity != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Capacity))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Players != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Players))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintTypes(dAtA []byte, offset int, v uint64) int {
 	offset -= sovTypes(v)
 	base := offset
 	for v >= 1<<7 {
@@ -384,31 +1016,254 @@ func (m *DeleteRequest) Size() (n int) {
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
-	return n
-}
+	return n
+}
+
+func (m *DeleteResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Success {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReloadConfigRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.GameID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	l = len(m.Config)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *ReloadConfigResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Success {
+		n += 2
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PlayersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.GameID)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *PlayersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Players != 0 {
+		n += 1 + sovTypes(uint64(m.Players))
+	}
+	if m.Capacity != 0 {
+		n += 1 + sovTypes(uint64(m.Capacity))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func sovTypes(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozTypes(x uint64) (n int) {
+	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *DeleteRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GameID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DeleteResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DeleteResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DeleteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Success", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Success = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
 
-func (m *DeleteResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Success {
-		n += 2
-	}
-	if m.XXX_unrecognized != nil {
-		n += len(m.XXX_unrecognized)
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovTypes(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozTypes(x uint64) (n int) {
-	return sovTypes(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *DeleteRequest) Unmarshal(dAtA []byte) error {
+func (m *ReloadConfigRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -431,10 +1286,10 @@ func (m *DeleteRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReloadConfigRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReloadConfigRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -469,6 +1324,38 @@ func (m *DeleteRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.GameID = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Config", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Config = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -491,7 +1378,7 @@ func (m *DeleteRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *DeleteResponse) Unmarshal(dAtA []byte) error {
+func (m *ReloadConfigResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -514,10 +1401,10 @@ func (m *DeleteResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: DeleteResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: ReloadConfigResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DeleteResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: ReloadConfigResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -562,6 +1449,178 @@ func (m *DeleteResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *PlayersRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlayersRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlayersRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GameID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.GameID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PlayersResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTypes
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PlayersResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PlayersResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Players", wireType)
+			}
+			m.Players = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Players |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Capacity", wireType)
+			}
+			m.Capacity = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Capacity |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTypes(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipTypes(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
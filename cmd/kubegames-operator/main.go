@@ -1,32 +1,58 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"flag"
+	"fmt"
 	"net/http"
+	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/kubegames/kubegames-operator/internal/pkg/log"
 	"github.com/kubegames/kubegames-operator/pkg/admission"
+	"github.com/kubegames/kubegames-operator/pkg/certificate"
+	gamesclientset "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned"
+	operatorconfig "github.com/kubegames/kubegames-operator/pkg/config"
+	"github.com/kubegames/kubegames-operator/pkg/controller"
 	"github.com/kubegames/kubegames-operator/pkg/game"
+	"github.com/kubegames/kubegames-operator/pkg/health"
+	"github.com/kubegames/kubegames-operator/pkg/metrics"
 	"github.com/kubegames/kubegames-operator/pkg/pod"
+	"github.com/kubegames/kubegames-operator/pkg/room"
 	"github.com/kubegames/kubegames-operator/pkg/signals"
 	"github.com/kubegames/kubegames-operator/pkg/webhook"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	"k8s.io/client-go/util/homedir"
 )
 
-const (
-	tlsDir      = `/run/secrets/tls`
-	tlsCertFile = `tls.crt`
-	tlsKeyFile  = `tls.key`
-)
-
 var (
-	cfg         string
-	kubeconfig  string
-	threadiness int
+	cfg                  string
+	kubeconfig           string
+	threadiness          int
+	namespaces           string
+	metricsAddr          string
+	healthAddr           string
+	leaderElect          bool
+	leaderElectNamespace string
+	leaderElectID        string
+	leaseDuration        time.Duration
+	renewDeadline        time.Duration
+	retryPeriod          time.Duration
+	operatorUsername     string
+	webhookNamespace     string
+	webhookService       string
+	webhookSecret        string
+	webhookConfig        string
 )
 
 func init() {
@@ -39,6 +65,21 @@ func init() {
 	}
 	flag.IntVar(&threadiness, "threadiness", 1, "kubegames controller worker threadiness")
 	flag.IntVar(&threadiness, "t", 1, "kubegames controller worker threadiness")
+	flag.StringVar(&namespaces, "namespaces", "", "comma separated namespaces the controllers watch, all namespaces if empty")
+	flag.StringVar(&metricsAddr, "metrics-addr", ":8080", "address the prometheus metrics endpoint binds to")
+	flag.StringVar(&healthAddr, "health-addr", ":8081", "address the healthz and readyz endpoints bind to")
+	flag.BoolVar(&leaderElect, "leader-elect", false, "run the controllers only on the replica holding the leader lease")
+	flag.StringVar(&leaderElectNamespace, "leader-elect-namespace", "default", "namespace of the leader lease")
+	flag.StringVar(&leaderElectID, "leader-elect-id", "kubegames-operator", "name of the leader lease")
+	flag.DurationVar(&leaseDuration, "leader-elect-lease-duration", 15*time.Second, "duration non-leader replicas wait before acquiring the lease")
+	flag.DurationVar(&renewDeadline, "leader-elect-renew-deadline", 10*time.Second, "duration the leader retries renewing the lease before giving up")
+	flag.DurationVar(&retryPeriod, "leader-elect-retry-period", 2*time.Second, "duration replicas wait between lease actions")
+	flag.StringVar(&webhookNamespace, "webhook-namespace", "default", "namespace of the webhook service and certificate secret")
+	flag.StringVar(&webhookService, "webhook-service", "kubegames-operator", "name of the webhook service the serving certificate is issued for")
+	flag.StringVar(&webhookSecret, "webhook-secret", "kubegames-operator-tls", "name of the secret holding the webhook ca and serving certificate")
+	flag.StringVar(&webhookConfig, "webhook-config", "kubegames-operator", "name of the validating and mutating webhook configurations the ca bundle is injected into")
+	flag.StringVar(&cfg, "config", "", "(optional) operator config file with the game defaults and sidecar profiles")
+	flag.StringVar(&operatorUsername, "operator-username", "system:serviceaccount:default:kubegames-operator", "user name of the operator, the only user allowed to remove the game finalizer, anyone if empty")
 }
 
 func main() {
@@ -47,9 +88,14 @@ func main() {
 	// handler signal
 	stopCh := signals.SetupSignalHandler()
 
+	//load operator config
+	operatorConfig, err := operatorconfig.Load(cfg)
+	if err != nil {
+		panic(err)
+	}
+
 	//nee k8s client
 	var config *rest.Config
-	var err error
 
 	if len(kubeconfig) > 0 {
 		if config, err = clientcmd.BuildConfigFromFlags("", kubeconfig); err != nil {
@@ -66,28 +112,132 @@ func main() {
 		panic(err)
 	}
 
-	//new game
-	game := game.NewGame(kubeClient, config)
-	go game.Run(threadiness, stopCh)
+	//new health
+	checker := health.New()
+	checker.AddHealthz(health.NamedCheck("ping", func(req *http.Request) error {
+		return nil
+	}))
+
+	gamesClient, err := gamesclientset.NewForConfig(config)
+	if err != nil {
+		panic(err)
+	}
+
+	//run controllers
+	var leading int32
+	run := func(ctx context.Context) {
+		atomic.StoreInt32(&leading, 1)
+
+		//namespaces are cluster scoped, shared by all watched namespaces
+		namespaceFactory := controller.NewNamespaceInformerFactory(kubeClient)
+
+		//controllers of every watched namespace share one set of informers
+		for _, namespace := range watchNamespaces() {
+			informers := controller.NewInformers(kubeClient, gamesClient, namespace, namespaceFactory)
+
+			//new controllers
+			game := game.NewGame(kubeClient, gamesClient, informers)
+			pod := pod.NewPod(kubeClient, gamesClient, informers)
+			room := room.NewRoom(kubeClient, gamesClient, informers)
 
-	//new pod
-	pod := pod.NewPod(kubeClient, config)
-	go pod.Run(threadiness, stopCh)
+			//start informers after all of them are registered
+			informers.Start(ctx.Done())
+
+			go game.Run(threadiness, ctx.Done())
+			go pod.Run(threadiness, ctx.Done())
+			go room.Run(threadiness, ctx.Done())
+
+			checker.AddReadyz(
+				informerCheck(informerName("game", namespace), game.HasSynced),
+				informerCheck(informerName("pod", namespace), pod.HasSynced),
+				informerCheck(informerName("room", namespace), room.HasSynced),
+			)
+
+			//workers stuck with keys queued fail liveness
+			checker.AddHealthz(
+				health.NamedCheck(workersName("game", namespace), game.Progress().Check),
+				health.NamedCheck(workersName("pod", namespace), pod.Progress().Check),
+				health.NamedCheck(workersName("room", namespace), room.Progress().Check),
+			)
+		}
+	}
+	checker.AddStatus("leader", func() string {
+		if atomic.LoadInt32(&leading) == 1 {
+			return "leading"
+		}
+		return "standby"
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	if leaderElect {
+		//lease held but not renewed fails liveness
+		electionChecker := leaderelection.NewLeaderHealthzAdaptor(time.Second * 20)
+		checker.AddHealthz(electionChecker)
+		go runLeaderElection(ctx, kubeClient, electionChecker, run)
+	} else {
+		run(ctx)
+	}
+
+	//new webhook
+	hook := webhook.NewWebhook(
+		webhook.KubeClientset(kubeClient),
+		webhook.GamesClientset(gamesClient),
+		webhook.GameDefaults(operatorConfig.GameDefaults),
+		webhook.SidecarProfiles(operatorConfig.SidecarProfiles, operatorConfig.DefaultSidecarProfile),
+		webhook.OperatorUsername(operatorUsername),
+	)
+
+	//webhook certificate, every replica serves the webhook
+	certs := certificate.NewManager(kubeClient, webhookNamespace, webhookService, webhookSecret, webhookConfig)
+	checker.AddReadyz(certs)
+	go certs.Run(stopCh)
 
 	//run http
 	go func() {
-		certPath := filepath.Join(tlsDir, tlsCertFile)
-		keyPath := filepath.Join(tlsDir, tlsKeyFile)
 		mux := http.NewServeMux()
-		mux.Handle("/validating", admission.AdmissionFuncHandler(webhook.Validating))
-		mux.Handle("/mutating", admission.AdmissionFuncHandler(webhook.Mutating))
+		mux.Handle("/validating", admission.AdmissionFuncHandler(hook.Validating))
+		mux.Handle("/mutating", admission.AdmissionFuncHandler(hook.Mutating))
+		mux.Handle("/mutating/pods", admission.AdmissionFuncHandler(hook.MutatingPods))
 
 		server := &http.Server{
-			Addr:    ":443",
+			Addr:      ":443",
+			Handler:   mux,
+			TLSConfig: &tls.Config{GetCertificate: certs.GetCertificate},
+		}
+
+		if err := server.ListenAndServeTLS("", ""); err != nil {
+			panic(err)
+		}
+	}()
+
+	//run health
+	go func() {
+		server := &http.Server{
+			Addr:    healthAddr,
+			Handler: checker.Handler(),
+		}
+
+		if err := server.ListenAndServe(); err != nil {
+			panic(err)
+		}
+	}()
+
+	//run metrics
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", metrics.Handler())
+
+		server := &http.Server{
+			Addr:    metricsAddr,
 			Handler: mux,
 		}
 
-		if err := server.ListenAndServeTLS(certPath, keyPath); err != nil {
+		if err := server.ListenAndServe(); err != nil {
 			panic(err)
 		}
 	}()
@@ -95,3 +245,92 @@ func main() {
 	log.Infof("kubegames operator start")
 	<-stopCh
 }
+
+//run controllers while holding the leader lease
+func runLeaderElection(ctx context.Context, kubeClient kubernetes.Interface, checker *leaderelection.HealthzAdaptor, run func(ctx context.Context)) {
+	id, err := os.Hostname()
+	if err != nil {
+		panic(err)
+	}
+	id = fmt.Sprintf("%s_%s", id, uuid.NewUUID())
+
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      leaderElectID,
+			Namespace: leaderElectNamespace,
+		},
+		Client: kubeClient.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: id,
+		},
+	}
+
+	leaderelection.RunOrDie(ctx, leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   leaseDuration,
+		RenewDeadline:   renewDeadline,
+		RetryPeriod:     retryPeriod,
+		WatchDog:        checker,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				log.Infof("leader %s start controllers", id)
+				run(ctx)
+			},
+			OnStoppedLeading: func() {
+				//shutdown
+				if ctx.Err() != nil {
+					log.Infof("leader %s released lease", id)
+					return
+				}
+				log.Fatalf("leader %s lost lease", id)
+			},
+			OnNewLeader: func(identity string) {
+				if identity != id {
+					log.Infof("new leader %s", identity)
+				}
+			},
+		},
+	})
+}
+
+//informer synced check
+func informerCheck(name string, synced func() bool) health.Checker {
+	return health.NamedCheck(name, func(req *http.Request) error {
+		if synced() == false {
+			return fmt.Errorf("%s cache not synced", name)
+		}
+		return nil
+	})
+}
+
+//namespaces the controllers watch, NamespaceAll if none is set
+func watchNamespaces() []string {
+	items := make([]string, 0)
+	for _, namespace := range strings.Split(namespaces, ",") {
+		if namespace = strings.TrimSpace(namespace); len(namespace) > 0 {
+			items = append(items, namespace)
+		}
+	}
+
+	if len(items) <= 0 {
+		return []string{metav1.NamespaceAll}
+	}
+	return items
+}
+
+//informer check name of namespace
+func informerName(name, namespace string) string {
+	if len(namespace) <= 0 {
+		return fmt.Sprintf("%s-informer", name)
+	}
+	return fmt.Sprintf("%s-informer-%s", name, namespace)
+}
+
+//name of the workers check of a controller in namespace
+func workersName(name, namespace string) string {
+	if len(namespace) <= 0 {
+		return fmt.Sprintf("%s-workers", name)
+	}
+	return fmt.Sprintf("%s-workers-%s", name, namespace)
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"time"
 
 	"github.com/kubegames/kubegames-operator/internal/pkg/log"
 	"github.com/kubegames/kubegames-operator/pkg/convert"
+	"github.com/kubegames/kubegames-operator/pkg/metrics"
 	"github.com/kubegames/kubegames-operator/pkg/scheme"
 	v1 "k8s.io/api/admission/v1"
 	"k8s.io/api/admission/v1beta1"
@@ -50,6 +52,13 @@ func delegateV1beta1AdmitToV1(f admitv1Func) admitv1beta1Func {
 
 // Serve method for webhook server
 func serve(w http.ResponseWriter, r *http.Request, admit admitHandler) {
+	//admission metrics
+	start := time.Now()
+	metrics.AdmissionTotal.WithLabelValues(r.URL.Path).Inc()
+	defer func() {
+		metrics.AdmissionDuration.WithLabelValues(r.URL.Path).Observe(time.Since(start).Seconds())
+	}()
+
 	var body []byte
 	if r.Body != nil {
 		if data, err := ioutil.ReadAll(r.Body); err == nil {
@@ -90,6 +99,9 @@ func serve(w http.ResponseWriter, r *http.Request, admit admitHandler) {
 		responseAdmissionReview.SetGroupVersionKind(*gvk)
 		responseAdmissionReview.Response = admit.v1beta1(*requestedAdmissionReview)
 		responseAdmissionReview.Response.UID = requestedAdmissionReview.Request.UID
+		if responseAdmissionReview.Response.Allowed == false {
+			metrics.AdmissionDenied.WithLabelValues(r.URL.Path).Inc()
+		}
 		responseObj = responseAdmissionReview
 	case v1.SchemeGroupVersion.WithKind("AdmissionReview"):
 		requestedAdmissionReview, ok := obj.(*v1.AdmissionReview)
@@ -101,6 +113,9 @@ func serve(w http.ResponseWriter, r *http.Request, admit admitHandler) {
 		responseAdmissionReview.SetGroupVersionKind(*gvk)
 		responseAdmissionReview.Response = admit.v1(*requestedAdmissionReview)
 		responseAdmissionReview.Response.UID = requestedAdmissionReview.Request.UID
+		if responseAdmissionReview.Response.Allowed == false {
+			metrics.AdmissionDenied.WithLabelValues(r.URL.Path).Inc()
+		}
 		responseObj = responseAdmissionReview
 	default:
 		msg := fmt.Sprintf("Unsupported group version kind: %v", gvk)
@@ -34,6 +34,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		SchemeGroupVersion,
 		&Game{},
 		&GameList{},
+		&Room{},
+		&RoomList{},
 	)
 
 	// register the type in the scheme
@@ -3,9 +3,12 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +genclient
+// +genclient:method=GetScale,verb=get,subresource=scale,result=k8s.io/api/autoscaling/v1.Scale
+// +genclient:method=UpdateScale,verb=update,subresource=scale,input=k8s.io/api/autoscaling/v1.Scale,result=k8s.io/api/autoscaling/v1.Scale
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type Game struct {
@@ -33,13 +36,95 @@ type GameSpec struct {
 	Commonds []string `json:"commonds,omitempty"`
 	//replicas
 	Replicas uint32 `json:"replicas,omitempty"`
+	//rolling update strategy
+	Strategy GameUpdateStrategy `json:"strategy,omitempty"`
+	//roll pods on config change instead of calling ReloadConfig
+	DisableHotReload bool `json:"disableHotReload,omitempty"`
+	//player based autoscaling
+	Autoscaling *GameAutoscaling `json:"autoscaling,omitempty"`
+	//how pods are asked to stop
+	Drain GameDrain `json:"drain,omitempty"`
 }
 
+//what to do with a pod still refusing to stop after the drain timeout
+type DrainPolicy string
+
+const (
+	//keep asking the pod to stop
+	DrainPolicyWait DrainPolicy = "Wait"
+	//delete the pod
+	DrainPolicyForce DrainPolicy = "Force"
+)
+
+type GameDrain struct {
+	//seconds a pod may refuse to stop(default 0, no timeout)
+	TimeoutSeconds uint32 `json:"timeoutSeconds,omitempty"`
+	//seconds between asking pods that refused to stop(default 15)
+	PollIntervalSeconds uint32 `json:"pollIntervalSeconds,omitempty"`
+	//policy after the timeout(default Wait)
+	Policy DrainPolicy `json:"policy,omitempty"`
+}
+
+type GameAutoscaling struct {
+	//minimum replicas(default 1)
+	MinReplicas uint32 `json:"minReplicas,omitempty"`
+	//maximum replicas
+	MaxReplicas uint32 `json:"maxReplicas"`
+	//target percent of players to capacity(default 80)
+	TargetUtilization uint32 `json:"targetUtilization,omitempty"`
+	//spare players kept above the online players
+	Buffer uint32 `json:"buffer,omitempty"`
+	//seconds between scaling up(default 30)
+	ScaleUpCooldownSeconds uint32 `json:"scaleUpCooldownSeconds,omitempty"`
+	//seconds after scaling before scaling down(default 300)
+	ScaleDownCooldownSeconds uint32 `json:"scaleDownCooldownSeconds,omitempty"`
+}
+
+type GameUpdateStrategy struct {
+	//maximum number of pods that can be unavailable during the update(default 25%)
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	//maximum number of pods that can be created over replicas during the update(default 25%)
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+}
+
+const (
+	//game has the minimum number of ready pods
+	GameAvailable = "Available"
+	//game is scaling or rolling pods
+	GameProgressing = "Progressing"
+	//game failed to sync or has failed pods
+	GameDegraded = "Degraded"
+	//game is waiting for pods to stop
+	GameDraining = "Draining"
+)
+
 type GamesStatus struct {
 	//pods
 	Pods map[string]*PodStatus `json:"pods,omitempty"`
 	//update time
 	UpdateAt string `json:"updateAt,omitempty"`
+	//revision of image and commonds the pods are rolled to
+	UpdateRevision string `json:"updateRevision,omitempty"`
+	//number of ready pods at update revision
+	UpdatedReplicas uint32 `json:"updatedReplicas,omitempty"`
+	//generation observed by the game controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	//number of pods
+	Replicas uint32 `json:"replicas,omitempty"`
+	//number of ready pods
+	ReadyReplicas uint32 `json:"readyReplicas,omitempty"`
+	//label selector of pods, used by the scale subresource
+	Selector string `json:"selector,omitempty"`
+	//conditions
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//online players reported by pods
+	Players uint32 `json:"players,omitempty"`
+	//maximum players reported by pods
+	Capacity uint32 `json:"capacity,omitempty"`
+	//last time the autoscaler changed replicas
+	LastScaleTime *metav1.Time `json:"lastScaleTime,omitempty"`
+	//name of the config map owned by the game
+	ConfigMap string `json:"configMap,omitempty"`
 }
 
 type PodStatus struct {
@@ -53,8 +138,14 @@ type PodStatus struct {
 	Port uint32 `json:"port,omitempty"`
 	//phase
 	Phase corev1.PodPhase `json:"phase,omitempty"`
+	//containers ready
+	Ready bool `json:"ready,omitempty"`
+	//revision
+	Revision string `json:"revision,omitempty"`
 	//reson
 	Events []string `json:"events,omitempty"`
+	//first time the pod was asked to stop
+	DrainStartTime *metav1.Time `json:"drainStartTime,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -66,3 +157,52 @@ type GameList struct {
 
 	Items []Game `json:"items"`
 }
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type Room struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoomSpec   `json:"spec"`
+	Status RoomStatus `json:"status"`
+}
+
+type RoomSpec struct {
+	//game union
+	GameID string `json:"gameID"`
+	//room union
+	RoomID string `json:"roomID"`
+	//this room config
+	Config string `json:"config,omitempty"`
+	//check room timeout
+	CheckTimeout bool `json:"checkTimeout,omitempty"`
+	//room monopolize a pod
+	Unique bool `json:"unique,omitempty"`
+}
+
+type RoomStatus struct {
+	//game name
+	Game string `json:"game,omitempty"`
+	//pod name
+	Pod string `json:"pod,omitempty"`
+	//host ip
+	HostIP string `json:"hostIp,omitempty"`
+	//pod ip
+	PodIP string `json:"podIp,omitempty"`
+	//port
+	Port uint32 `json:"port,omitempty"`
+	//update time
+	UpdateAt string `json:"updateAt,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// RoomList is a list of Room resources
+type RoomList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Room `json:"items"`
+}
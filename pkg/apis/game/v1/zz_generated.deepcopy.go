@@ -21,7 +21,9 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -52,6 +54,38 @@ func (in *Game) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameAutoscaling) DeepCopyInto(out *GameAutoscaling) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameAutoscaling.
+func (in *GameAutoscaling) DeepCopy() *GameAutoscaling {
+	if in == nil {
+		return nil
+	}
+	out := new(GameAutoscaling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameDrain) DeepCopyInto(out *GameDrain) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameDrain.
+func (in *GameDrain) DeepCopy() *GameDrain {
+	if in == nil {
+		return nil
+	}
+	out := new(GameDrain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GameList) DeepCopyInto(out *GameList) {
 	*out = *in
@@ -93,6 +127,13 @@ func (in *GameSpec) DeepCopyInto(out *GameSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(GameAutoscaling)
+		**out = **in
+	}
+	out.Drain = in.Drain
 	return
 }
 
@@ -106,6 +147,32 @@ func (in *GameSpec) DeepCopy() *GameSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GameUpdateStrategy) DeepCopyInto(out *GameUpdateStrategy) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GameUpdateStrategy.
+func (in *GameUpdateStrategy) DeepCopy() *GameUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(GameUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GamesStatus) DeepCopyInto(out *GamesStatus) {
 	*out = *in
@@ -124,6 +191,17 @@ func (in *GamesStatus) DeepCopyInto(out *GamesStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScaleTime != nil {
+		in, out := &in.LastScaleTime, &out.LastScaleTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -145,6 +223,10 @@ func (in *PodStatus) DeepCopyInto(out *PodStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DrainStartTime != nil {
+		in, out := &in.DrainStartTime, &out.DrainStartTime
+		*out = (*in).DeepCopy()
+	}
 	return
 }
 
@@ -157,3 +239,96 @@ func (in *PodStatus) DeepCopy() *PodStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Room) DeepCopyInto(out *Room) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Room.
+func (in *Room) DeepCopy() *Room {
+	if in == nil {
+		return nil
+	}
+	out := new(Room)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Room) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoomList) DeepCopyInto(out *RoomList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Room, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoomList.
+func (in *RoomList) DeepCopy() *RoomList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoomList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoomList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoomSpec) DeepCopyInto(out *RoomSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoomSpec.
+func (in *RoomSpec) DeepCopy() *RoomSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoomSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoomStatus) DeepCopyInto(out *RoomStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoomStatus.
+func (in *RoomStatus) DeepCopy() *RoomStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoomStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,289 @@
+package certificate
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	//interval of checking the secret, rotations of other replicas are loaded in time
+	syncInterval = time.Minute
+	//secret keys of the ca, the serving certificate uses tls.crt and tls.key
+	CACertKey         = "ca.crt"
+	CAKeyKey          = "ca.key"
+	PreviousCACertKey = "ca-previous.crt"
+)
+
+// Manager keeps the webhook serving certificate in a secret, rotates it and
+// injects the ca bundle into the webhook configurations
+type Manager struct {
+	// kubeclientset is a standard kubernetes clientset
+	kubeclientset kubernetes.Interface
+	//namespace of the service and secret
+	namespace string
+	//webhook service name
+	service string
+	//secret name
+	secret string
+	//name of the validating and mutating webhook configurations
+	webhook string
+	//current serving certificate
+	certificate atomic.Value
+}
+
+// returns a new certificate manager
+func NewManager(kubeclientset kubernetes.Interface, namespace, service, secret, webhook string) *Manager {
+	return &Manager{
+		kubeclientset: kubeclientset,
+		namespace:     namespace,
+		service:       service,
+		secret:        secret,
+		webhook:       webhook,
+	}
+}
+
+//run
+func (m *Manager) Run(stopCh <-chan struct{}) {
+	defer runtime.HandleCrash()
+
+	log.Infoln("certificate manager start")
+	wait.Until(func() {
+		if err := m.sync(context.Background()); err != nil {
+			log.Errorf("sync webhook certificate error %s", err.Error())
+		}
+	}, syncInterval, stopCh)
+	log.Infoln("certificate manager end")
+}
+
+//serving certificate for the tls server, reloaded without restart
+func (m *Manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cert, ok := m.certificate.Load().(*tls.Certificate)
+	if !ok {
+		return nil, fmt.Errorf("webhook tls certificate not loaded")
+	}
+	return cert, nil
+}
+
+//health check name
+func (m *Manager) Name() string {
+	return "webhook-tls"
+}
+
+//ready when the serving certificate is loaded
+func (m *Manager) Check(req *http.Request) error {
+	_, err := m.GetCertificate(nil)
+	return err
+}
+
+//dns names of the webhook service
+func (m *Manager) dnsNames() []string {
+	return []string{
+		fmt.Sprintf("%s.%s.svc", m.service, m.namespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", m.service, m.namespace),
+		fmt.Sprintf("%s.%s", m.service, m.namespace),
+		m.service,
+	}
+}
+
+//rotate the secret if needed, inject the ca bundle and load the serving certificate. the new
+//certificate is served only after both webhook configurations trust its ca
+func (m *Manager) sync(ctx context.Context) error {
+	secret, err := m.ensureSecret(ctx)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.X509KeyPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		return err
+	}
+
+	//the previous ca stays trusted until it expires
+	bundle := append(append([]byte{}, secret.Data[CACertKey]...), secret.Data[PreviousCACertKey]...)
+	if err := m.injectValidating(ctx, bundle); err != nil {
+		return err
+	}
+	if err := m.injectMutating(ctx, bundle); err != nil {
+		return err
+	}
+
+	//load serving certificate
+	if current, ok := m.certificate.Load().(*tls.Certificate); !ok || bytes.Equal(current.Certificate[0], cert.Certificate[0]) == false {
+		m.certificate.Store(&cert)
+		log.Infof("webhook certificate loaded from secret %s/%s", m.namespace, m.secret)
+	}
+	return nil
+}
+
+//get the secret, create or rotate the certificates in it
+func (m *Manager) ensureSecret(ctx context.Context) (*corev1.Secret, error) {
+	secret, err := m.kubeclientset.CoreV1().Secrets(m.namespace).Get(ctx, m.secret, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) == false {
+			return nil, err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: m.secret, Namespace: m.namespace},
+			Type:       corev1.SecretTypeTLS,
+		}
+	}
+
+	data, rotated, err := m.renew(secret.Data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !rotated {
+		return secret, nil
+	}
+
+	newsecret := secret.DeepCopy()
+	newsecret.Data = data
+
+	//create
+	if len(secret.ResourceVersion) <= 0 {
+		created, err := m.kubeclientset.CoreV1().Secrets(m.namespace).Create(ctx, newsecret, metav1.CreateOptions{})
+		if err != nil {
+			//created by another replica
+			if errors.IsAlreadyExists(err) {
+				return m.kubeclientset.CoreV1().Secrets(m.namespace).Get(ctx, m.secret, metav1.GetOptions{})
+			}
+			return nil, err
+		}
+		log.Infof("webhook certificate created in secret %s/%s", m.namespace, m.secret)
+		return created, nil
+	}
+
+	//update
+	updated, err := m.kubeclientset.CoreV1().Secrets(m.namespace).Update(ctx, newsecret, metav1.UpdateOptions{})
+	if err != nil {
+		//rotated by another replica
+		if errors.IsConflict(err) {
+			return m.kubeclientset.CoreV1().Secrets(m.namespace).Get(ctx, m.secret, metav1.GetOptions{})
+		}
+		return nil, err
+	}
+	log.Infof("webhook certificate rotated in secret %s/%s", m.namespace, m.secret)
+	return updated, nil
+}
+
+//renew the expiring, missing or invalid certificates of data
+func (m *Manager) renew(old map[string][]byte, now time.Time) (map[string][]byte, bool, error) {
+	data := make(map[string][]byte, len(old))
+	for key, value := range old {
+		data[key] = value
+	}
+	rotated := false
+
+	//ca
+	ca, caErr := parseCertificate(data[CACertKey])
+	caKey, keyErr := parsePrivateKey(data[CAKeyKey])
+	if caErr != nil || keyErr != nil || needsRenew(ca, now) {
+		//clients keep trusting the old ca until the serving certificates are rotated everywhere
+		delete(data, PreviousCACertKey)
+		if caErr == nil && now.Before(ca.NotAfter) {
+			data[PreviousCACertKey] = old[CACertKey]
+		}
+
+		certPEM, keyPEM, err := newCA(fmt.Sprintf("%s-ca", m.service), now)
+		if err != nil {
+			return nil, false, err
+		}
+		data[CACertKey], data[CAKeyKey] = certPEM, keyPEM
+		if ca, err = parseCertificate(certPEM); err != nil {
+			return nil, false, err
+		}
+		if caKey, err = parsePrivateKey(keyPEM); err != nil {
+			return nil, false, err
+		}
+		rotated = true
+	}
+
+	//serving certificate
+	serving, err := parseCertificate(data[corev1.TLSCertKey])
+	_, keyErr = parsePrivateKey(data[corev1.TLSPrivateKeyKey])
+	if rotated || err != nil || keyErr != nil || needsRenew(serving, now) || serving.CheckSignatureFrom(ca) != nil || coversNames(serving, m.dnsNames()) == false {
+		certPEM, keyPEM, err := newServing(ca, caKey, m.dnsNames(), now)
+		if err != nil {
+			return nil, false, err
+		}
+		data[corev1.TLSCertKey], data[corev1.TLSPrivateKeyKey] = certPEM, keyPEM
+		rotated = true
+	}
+
+	//drop the expired previous ca
+	if previous, ok := data[PreviousCACertKey]; ok {
+		if cert, err := parseCertificate(previous); err != nil || now.After(cert.NotAfter) {
+			delete(data, PreviousCACertKey)
+			rotated = true
+		}
+	}
+	return data, rotated, nil
+}
+
+//set the ca bundle of all webhooks in the validating webhook configuration
+func (m *Manager) injectValidating(ctx context.Context, bundle []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config, err := m.kubeclientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(ctx, m.webhook, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for i := range config.Webhooks {
+			if bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, bundle) == false {
+				config.Webhooks[i].ClientConfig.CABundle = bundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		if _, err := m.kubeclientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().Update(ctx, config, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		log.Infof("validating webhook configuration %s ca bundle injected", m.webhook)
+		return nil
+	})
+}
+
+//set the ca bundle of all webhooks in the mutating webhook configuration
+func (m *Manager) injectMutating(ctx context.Context, bundle []byte) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		config, err := m.kubeclientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(ctx, m.webhook, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for i := range config.Webhooks {
+			if bytes.Equal(config.Webhooks[i].ClientConfig.CABundle, bundle) == false {
+				config.Webhooks[i].ClientConfig.CABundle = bundle
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+
+		if _, err := m.kubeclientset.AdmissionregistrationV1().MutatingWebhookConfigurations().Update(ctx, config, metav1.UpdateOptions{}); err != nil {
+			return err
+		}
+		log.Infof("mutating webhook configuration %s ca bundle injected", m.webhook)
+		return nil
+	})
+}
@@ -0,0 +1,175 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+//serving certificate of data verifies against the ca bundle of data at now
+func verifyServing(t *testing.T, m *Manager, data map[string][]byte, serving []byte, now time.Time) error {
+	t.Helper()
+	pool := x509.NewCertPool()
+	if pool.AppendCertsFromPEM(append(append([]byte{}, data[CACertKey]...), data[PreviousCACertKey]...)) == false {
+		t.Fatalf("invalid ca bundle")
+	}
+	cert, err := parseCertificate(serving)
+	if err != nil {
+		t.Fatalf("invalid serving certificate: %s", err.Error())
+	}
+	_, err = cert.Verify(x509.VerifyOptions{DNSName: m.dnsNames()[0], Roots: pool, CurrentTime: now})
+	return err
+}
+
+func TestRenew(t *testing.T) {
+	m := &Manager{namespace: "default", service: "kubegames-operator", secret: "kubegames-operator-tls"}
+	now := time.Now()
+
+	initial, rotated, err := m.renew(nil, now)
+	if err != nil || !rotated {
+		t.Fatalf("renew empty secret = %v, %v, want rotated", rotated, err)
+	}
+	ca, _ := parseCertificate(initial[CACertKey])
+	serving, _ := parseCertificate(initial[corev1.TLSCertKey])
+
+	tests := []struct {
+		name     string
+		data     func() map[string][]byte
+		now      time.Time
+		rotated  bool
+		newCA    bool
+		previous bool
+	}{
+		{
+			name:    "valid",
+			data:    func() map[string][]byte { return initial },
+			now:     now.Add(time.Hour),
+			rotated: false,
+		},
+		{
+			name:    "serving certificate expiring",
+			data:    func() map[string][]byte { return initial },
+			now:     serving.NotAfter.Add(-time.Hour),
+			rotated: true,
+		},
+		{
+			name: "serving certificate for other names",
+			data: func() map[string][]byte {
+				certPEM, keyPEM, err := newServing(ca, mustKey(t, initial[CAKeyKey]), []string{"other.default.svc"}, now)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return map[string][]byte{CACertKey: initial[CACertKey], CAKeyKey: initial[CAKeyKey], corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}
+			},
+			now:     now,
+			rotated: true,
+		},
+		{
+			name: "ca expiring keeps the previous ca",
+			data: func() map[string][]byte {
+				//serving certificate renewed shortly before
+				certPEM, keyPEM, err := newServing(ca, mustKey(t, initial[CAKeyKey]), m.dnsNames(), ca.NotAfter.Add(-time.Hour*2))
+				if err != nil {
+					t.Fatal(err)
+				}
+				return map[string][]byte{CACertKey: initial[CACertKey], CAKeyKey: initial[CAKeyKey], corev1.TLSCertKey: certPEM, corev1.TLSPrivateKeyKey: keyPEM}
+			},
+			now:      ca.NotAfter.Add(-time.Hour),
+			rotated:  true,
+			newCA:    true,
+			previous: true,
+		},
+		{
+			name: "invalid ca",
+			data: func() map[string][]byte {
+				return map[string][]byte{CACertKey: []byte("invalid"), corev1.TLSCertKey: initial[corev1.TLSCertKey], corev1.TLSPrivateKeyKey: initial[corev1.TLSPrivateKeyKey]}
+			},
+			now:      now,
+			rotated:  true,
+			newCA:    true,
+			previous: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			old := test.data()
+			data, rotated, err := m.renew(old, test.now)
+			if err != nil {
+				t.Fatalf("renew error %s", err.Error())
+			}
+			if rotated != test.rotated {
+				t.Fatalf("rotated = %v, want %v", rotated, test.rotated)
+			}
+			if newCA := bytes.Equal(data[CACertKey], old[CACertKey]) == false; newCA != test.newCA {
+				t.Errorf("new ca = %v, want %v", newCA, test.newCA)
+			}
+			if _, previous := data[PreviousCACertKey]; previous != test.previous {
+				t.Errorf("previous ca = %v, want %v", previous, test.previous)
+			}
+			if test.previous && bytes.Equal(data[PreviousCACertKey], old[CACertKey]) == false {
+				t.Errorf("previous ca is not the old ca")
+			}
+
+			//the new serving certificate is trusted
+			if err := verifyServing(t, m, data, data[corev1.TLSCertKey], test.now); err != nil {
+				t.Errorf("serving certificate not trusted: %s", err.Error())
+			}
+
+			//the old serving certificate stays trusted while the old ca is kept
+			if test.previous {
+				if err := verifyServing(t, m, data, old[corev1.TLSCertKey], test.now); err != nil {
+					t.Errorf("old serving certificate not trusted: %s", err.Error())
+				}
+			}
+		})
+	}
+}
+
+func TestRenewDropsExpiredPreviousCA(t *testing.T) {
+	m := &Manager{namespace: "default", service: "kubegames-operator", secret: "kubegames-operator-tls"}
+	now := time.Now()
+
+	initial, _, err := m.renew(nil, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := parseCertificate(initial[CACertKey])
+
+	//rotate the ca, the old one is kept
+	rotated, _, err := m.renew(initial, ca.NotAfter.Add(-time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rotated[PreviousCACertKey]; !ok {
+		t.Fatalf("previous ca not kept")
+	}
+
+	//the old ca expired
+	data, changed, err := m.renew(rotated, ca.NotAfter.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Errorf("rotated = false, want true")
+	}
+	if _, ok := data[PreviousCACertKey]; ok {
+		t.Errorf("expired previous ca kept")
+	}
+	if bytes.Equal(data[CACertKey], rotated[CACertKey]) == false {
+		t.Errorf("ca rotated again")
+	}
+}
+
+func mustKey(t *testing.T, data []byte) crypto.Signer {
+	t.Helper()
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
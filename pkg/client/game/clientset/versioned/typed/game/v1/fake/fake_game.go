@@ -21,6 +21,7 @@ import (
 	"context"
 
 	gamev1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
@@ -139,3 +140,25 @@ func (c *FakeGames) Patch(ctx context.Context, name string, pt types.PatchType,
 	}
 	return obj.(*gamev1.Game), err
 }
+
+// GetScale takes name of the game, and returns the corresponding scale object, and an error if there is any.
+func (c *FakeGames) GetScale(ctx context.Context, gameName string, options v1.GetOptions) (result *autoscalingv1.Scale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetSubresourceAction(gamesResource, c.ns, "scale", gameName), &autoscalingv1.Scale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
+
+// UpdateScale takes the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.
+func (c *FakeGames) UpdateScale(ctx context.Context, gameName string, scale *autoscalingv1.Scale, opts v1.UpdateOptions) (result *autoscalingv1.Scale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(gamesResource, "scale", c.ns, scale), &autoscalingv1.Scale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*autoscalingv1.Scale), err
+}
@@ -31,6 +31,10 @@ func (c *FakeKubegamesV1) Games(namespace string) v1.GameInterface {
 	return &FakeGames{c, namespace}
 }
 
+func (c *FakeKubegamesV1) Rooms(namespace string) v1.RoomInterface {
+	return &FakeRooms{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKubegamesV1) RESTClient() rest.Interface {
@@ -0,0 +1,141 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	gamev1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeRooms implements RoomInterface
+type FakeRooms struct {
+	Fake *FakeKubegamesV1
+	ns   string
+}
+
+var roomsResource = schema.GroupVersionResource{Group: "kubegames.com", Version: "v1", Resource: "rooms"}
+
+var roomsKind = schema.GroupVersionKind{Group: "kubegames.com", Version: "v1", Kind: "Room"}
+
+// Get takes name of the room, and returns the corresponding room object, and an error if there is any.
+func (c *FakeRooms) Get(ctx context.Context, name string, options v1.GetOptions) (result *gamev1.Room, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(roomsResource, c.ns, name), &gamev1.Room{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*gamev1.Room), err
+}
+
+// List takes label and field selectors, and returns the list of Rooms that match those selectors.
+func (c *FakeRooms) List(ctx context.Context, opts v1.ListOptions) (result *gamev1.RoomList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(roomsResource, roomsKind, c.ns, opts), &gamev1.RoomList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &gamev1.RoomList{ListMeta: obj.(*gamev1.RoomList).ListMeta}
+	for _, item := range obj.(*gamev1.RoomList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested rooms.
+func (c *FakeRooms) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(roomsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a room and creates it.  Returns the server's representation of the room, and an error, if there is any.
+func (c *FakeRooms) Create(ctx context.Context, room *gamev1.Room, opts v1.CreateOptions) (result *gamev1.Room, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(roomsResource, c.ns, room), &gamev1.Room{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*gamev1.Room), err
+}
+
+// Update takes the representation of a room and updates it. Returns the server's representation of the room, and an error, if there is any.
+func (c *FakeRooms) Update(ctx context.Context, room *gamev1.Room, opts v1.UpdateOptions) (result *gamev1.Room, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(roomsResource, c.ns, room), &gamev1.Room{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*gamev1.Room), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeRooms) UpdateStatus(ctx context.Context, room *gamev1.Room, opts v1.UpdateOptions) (*gamev1.Room, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(roomsResource, "status", c.ns, room), &gamev1.Room{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*gamev1.Room), err
+}
+
+// Delete takes name of the room and deletes it. Returns an error if one occurs.
+func (c *FakeRooms) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteActionWithOptions(roomsResource, c.ns, name, opts), &gamev1.Room{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeRooms) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(roomsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &gamev1.RoomList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched room.
+func (c *FakeRooms) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *gamev1.Room, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(roomsResource, c.ns, name, pt, data, subresources...), &gamev1.Room{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*gamev1.Room), err
+}
@@ -23,6 +23,7 @@ import (
 
 	v1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
 	scheme "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned/scheme"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
@@ -46,6 +47,9 @@ type GameInterface interface {
 	List(ctx context.Context, opts metav1.ListOptions) (*v1.GameList, error)
 	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
 	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.Game, err error)
+	GetScale(ctx context.Context, gameName string, options metav1.GetOptions) (*autoscalingv1.Scale, error)
+	UpdateScale(ctx context.Context, gameName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (*autoscalingv1.Scale, error)
+
 	GameExpansion
 }
 
@@ -192,3 +196,32 @@ func (c *games) Patch(ctx context.Context, name string, pt types.PatchType, data
 		Into(result)
 	return
 }
+
+// GetScale takes name of the game, and returns the corresponding autoscalingv1.Scale object, and an error if there is any.
+func (c *games) GetScale(ctx context.Context, gameName string, options metav1.GetOptions) (result *autoscalingv1.Scale, err error) {
+	result = &autoscalingv1.Scale{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("games").
+		Name(gameName).
+		SubResource("scale").
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateScale takes the top resource name and the representation of a scale and updates it. Returns the server's representation of the scale, and an error, if there is any.
+func (c *games) UpdateScale(ctx context.Context, gameName string, scale *autoscalingv1.Scale, opts metav1.UpdateOptions) (result *autoscalingv1.Scale, err error) {
+	result = &autoscalingv1.Scale{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("games").
+		Name(gameName).
+		SubResource("scale").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(scale).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -28,6 +28,7 @@ import (
 type KubegamesV1Interface interface {
 	RESTClient() rest.Interface
 	GamesGetter
+	RoomsGetter
 }
 
 // KubegamesV1Client is used to interact with features provided by the kubegames.com group.
@@ -39,6 +40,10 @@ func (c *KubegamesV1Client) Games(namespace string) GameInterface {
 	return newGames(c, namespace)
 }
 
+func (c *KubegamesV1Client) Rooms(namespace string) RoomInterface {
+	return newRooms(c, namespace)
+}
+
 // NewForConfig creates a new KubegamesV1Client for the given config.
 // NewForConfig is equivalent to NewForConfigAndClient(c, httpClient),
 // where httpClient was generated with rest.HTTPClientFor(c).
@@ -0,0 +1,194 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	v1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	scheme "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// RoomsGetter has a method to return a RoomInterface.
+// A group's client should implement this interface.
+type RoomsGetter interface {
+	Rooms(namespace string) RoomInterface
+}
+
+// RoomInterface has methods to work with Room resources.
+type RoomInterface interface {
+	Create(ctx context.Context, room *v1.Room, opts metav1.CreateOptions) (*v1.Room, error)
+	Update(ctx context.Context, room *v1.Room, opts metav1.UpdateOptions) (*v1.Room, error)
+	UpdateStatus(ctx context.Context, room *v1.Room, opts metav1.UpdateOptions) (*v1.Room, error)
+	Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error
+	Get(ctx context.Context, name string, opts metav1.GetOptions) (*v1.Room, error)
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.RoomList, error)
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.Room, err error)
+	RoomExpansion
+}
+
+// rooms implements RoomInterface
+type rooms struct {
+	client rest.Interface
+	ns     string
+}
+
+// newRooms returns a Rooms
+func newRooms(c *KubegamesV1Client, namespace string) *rooms {
+	return &rooms{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the room, and returns the corresponding room object, and an error if there is any.
+func (c *rooms) Get(ctx context.Context, name string, options metav1.GetOptions) (result *v1.Room, err error) {
+	result = &v1.Room{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("rooms").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Rooms that match those selectors.
+func (c *rooms) List(ctx context.Context, opts metav1.ListOptions) (result *v1.RoomList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.RoomList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("rooms").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested rooms.
+func (c *rooms) Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("rooms").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a room and creates it.  Returns the server's representation of the room, and an error, if there is any.
+func (c *rooms) Create(ctx context.Context, room *v1.Room, opts metav1.CreateOptions) (result *v1.Room, err error) {
+	result = &v1.Room{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("rooms").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(room).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a room and updates it. Returns the server's representation of the room, and an error, if there is any.
+func (c *rooms) Update(ctx context.Context, room *v1.Room, opts metav1.UpdateOptions) (result *v1.Room, err error) {
+	result = &v1.Room{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("rooms").
+		Name(room.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(room).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *rooms) UpdateStatus(ctx context.Context, room *v1.Room, opts metav1.UpdateOptions) (result *v1.Room, err error) {
+	result = &v1.Room{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("rooms").
+		Name(room.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(room).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the room and deletes it. Returns an error if one occurs.
+func (c *rooms) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("rooms").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *rooms) DeleteCollection(ctx context.Context, opts metav1.DeleteOptions, listOpts metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("rooms").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched room.
+func (c *rooms) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts metav1.PatchOptions, subresources ...string) (result *v1.Room, err error) {
+	result = &v1.Room{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("rooms").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
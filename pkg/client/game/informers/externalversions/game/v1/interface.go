@@ -25,6 +25,8 @@ import (
 type Interface interface {
 	// Games returns a GameInformer.
 	Games() GameInformer
+	// Rooms returns a RoomInformer.
+	Rooms() RoomInformer
 }
 
 type version struct {
@@ -42,3 +44,8 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Games() GameInformer {
 	return &gameInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// Rooms returns a RoomInformer.
+func (v *version) Rooms() RoomInformer {
+	return &roomInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
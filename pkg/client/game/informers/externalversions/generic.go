@@ -54,6 +54,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=kubegames.com, Version=v1
 	case v1.SchemeGroupVersion.WithResource("games"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubegames().V1().Games().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("rooms"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kubegames().V1().Rooms().Informer()}, nil
 
 	}
 
@@ -24,3 +24,11 @@ type GameListerExpansion interface{}
 // GameNamespaceListerExpansion allows custom methods to be added to
 // GameNamespaceLister.
 type GameNamespaceListerExpansion interface{}
+
+// RoomListerExpansion allows custom methods to be added to
+// RoomLister.
+type RoomListerExpansion interface{}
+
+// RoomNamespaceListerExpansion allows custom methods to be added to
+// RoomNamespaceLister.
+type RoomNamespaceListerExpansion interface{}
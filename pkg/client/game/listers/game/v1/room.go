@@ -0,0 +1,98 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// RoomLister helps list Rooms.
+// All objects returned here must be treated as read-only.
+type RoomLister interface {
+	// List lists all Rooms in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.Room, err error)
+	// Rooms returns an object that can list and get Rooms.
+	Rooms(namespace string) RoomNamespaceLister
+	RoomListerExpansion
+}
+
+// roomLister implements the RoomLister interface.
+type roomLister struct {
+	indexer cache.Indexer
+}
+
+// NewRoomLister returns a new RoomLister.
+func NewRoomLister(indexer cache.Indexer) RoomLister {
+	return &roomLister{indexer: indexer}
+}
+
+// List lists all Rooms in the indexer.
+func (s *roomLister) List(selector labels.Selector) (ret []*v1.Room, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.Room))
+	})
+	return ret, err
+}
+
+// Rooms returns an object that can list and get Rooms.
+func (s *roomLister) Rooms(namespace string) RoomNamespaceLister {
+	return roomNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// RoomNamespaceLister helps list and get Rooms.
+// All objects returned here must be treated as read-only.
+type RoomNamespaceLister interface {
+	// List lists all Rooms in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1.Room, err error)
+	// Get retrieves the Room from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1.Room, error)
+	RoomNamespaceListerExpansion
+}
+
+// roomNamespaceLister implements the RoomNamespaceLister
+// interface.
+type roomNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all Rooms in the indexer for a given namespace.
+func (s roomNamespaceLister) List(selector labels.Selector) (ret []*v1.Room, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.Room))
+	})
+	return ret, err
+}
+
+// Get retrieves the Room from the indexer for a given namespace and name.
+func (s roomNamespaceLister) Get(name string) (*v1.Room, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("room"), name)
+	}
+	return obj.(*v1.Room), nil
+}
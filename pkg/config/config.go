@@ -0,0 +1,80 @@
+package config
+
+import (
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type (
+	//operator config file
+	Config struct {
+		//defaults of game fields not set on create
+		GameDefaults GameDefaults `json:"gameDefaults,omitempty"`
+		//sidecar profiles by name
+		SidecarProfiles map[string]SidecarProfile `json:"sidecarProfiles,omitempty"`
+		//profile of game pods without a profile annotation, none if empty
+		DefaultSidecarProfile string `json:"defaultSidecarProfile,omitempty"`
+	}
+
+	//defaults of game fields, 0 leaves the field unset
+	GameDefaults struct {
+		//port
+		Port uint32 `json:"port,omitempty"`
+		//maximum cpu allowed(1000 = 1cpu)
+		Cpu uint32 `json:"cpu,omitempty"`
+		//maximum memory allowed(1=1Mi)
+		Memory uint32 `json:"memory,omitempty"`
+		//replicas
+		Replicas uint32 `json:"replicas,omitempty"`
+	}
+
+	//platform containers and settings injected into game pods
+	SidecarProfile struct {
+		//containers added to game pods
+		Containers []corev1.Container `json:"containers,omitempty"`
+		//init containers run before the game containers
+		InitContainers []corev1.Container `json:"initContainers,omitempty"`
+		//env added to the game containers
+		Env []corev1.EnvVar `json:"env,omitempty"`
+		//volumes added to game pods
+		Volumes []corev1.Volume `json:"volumes,omitempty"`
+		//volume mounts added to the game containers
+		VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty"`
+	}
+)
+
+//load config file, an empty path is an empty config
+func Load(path string) (*Config, error) {
+	cfg := new(Config)
+	if len(path) <= 0 {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+//defaults overridden by the fields set in other
+func (d GameDefaults) Merge(other GameDefaults) GameDefaults {
+	if other.Port > 0 {
+		d.Port = other.Port
+	}
+	if other.Cpu > 0 {
+		d.Cpu = other.Cpu
+	}
+	if other.Memory > 0 {
+		d.Memory = other.Memory
+	}
+	if other.Replicas > 0 {
+		d.Replicas = other.Replicas
+	}
+	return d
+}
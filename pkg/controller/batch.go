@@ -0,0 +1,44 @@
+package controller
+
+import "sync"
+
+//size of the first batch of SlowStartBatch
+const SlowStartInitialBatchSize = 1
+
+//call fn count times in parallel batches starting from initialBatchSize and doubling after every
+//successful batch, so a failing call (quota, admission) fails once instead of count times.
+//fn gets the index of the call, it returns the successful calls and the first error
+func SlowStartBatch(count int, initialBatchSize int, fn func(index int) error) (int, error) {
+	remaining := count
+	successes := 0
+	index := 0
+	for batchSize := min(remaining, initialBatchSize); batchSize > 0; batchSize = min(2*batchSize, remaining) {
+		errCh := make(chan error, batchSize)
+		var wg sync.WaitGroup
+		wg.Add(batchSize)
+		for i := 0; i < batchSize; i++ {
+			go func(index int) {
+				defer wg.Done()
+				if err := fn(index); err != nil {
+					errCh <- err
+				}
+			}(index + i)
+		}
+		wg.Wait()
+		index += batchSize
+
+		successes += batchSize - len(errCh)
+		if len(errCh) > 0 {
+			return successes, <-errCh
+		}
+		remaining -= batchSize
+	}
+	return successes, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSlowStartBatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		count     int
+		fail      func(index int) bool
+		successes int
+		calls     int32
+		err       bool
+	}{
+		{
+			name:      "no calls",
+			count:     0,
+			fail:      func(index int) bool { return false },
+			successes: 0,
+			calls:     0,
+		},
+		{
+			name:      "all succeed",
+			count:     10,
+			fail:      func(index int) bool { return false },
+			successes: 10,
+			calls:     10,
+		},
+		{
+			name:      "first call fails",
+			count:     10,
+			fail:      func(index int) bool { return true },
+			successes: 0,
+			calls:     1,
+			err:       true,
+		},
+		{
+			//batches 1, 2, 4: the batch of index 3 to 6 runs to the end
+			name:      "error mid batch",
+			count:     10,
+			fail:      func(index int) bool { return index == 4 },
+			successes: 6,
+			calls:     7,
+			err:       true,
+		},
+		{
+			name:      "errors in the last batch",
+			count:     10,
+			fail:      func(index int) bool { return index >= 8 },
+			successes: 8,
+			calls:     10,
+			err:       true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls int32
+			successes, err := SlowStartBatch(test.count, SlowStartInitialBatchSize, func(index int) error {
+				atomic.AddInt32(&calls, 1)
+				if test.fail(index) {
+					return fmt.Errorf("call %d failed", index)
+				}
+				return nil
+			})
+			if successes != test.successes {
+				t.Errorf("successes = %d, want %d", successes, test.successes)
+			}
+			if calls != test.calls {
+				t.Errorf("calls = %d, want %d", calls, test.calls)
+			}
+			if (err != nil) != test.err {
+				t.Errorf("err = %v, want error %v", err, test.err)
+			}
+		})
+	}
+}
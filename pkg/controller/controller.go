@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	"github.com/kubegames/kubegames-operator/pkg/metrics"
+	"k8s.io/client-go/util/workqueue"
+)
+
+//retries of a key before its failures are logged as errors, keys are never dropped since the
+//resync does not enqueue an unchanged object again
+const MaxRetries = 15
+
+type (
+	//sync handler of a namespace/name key
+	SyncHandler func(ctx context.Context, key string) error
+
+	//error asking to sync the key again after a delay, it does not count as a failure
+	RequeueError interface {
+		error
+		RequeueAfter() time.Duration
+	}
+)
+
+//new rate limiting queue with per key exponential backoff
+func NewQueue(name string) workqueue.RateLimitingInterface {
+	return workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), name)
+}
+
+//process next work item of queue, return false when the queue shuts down
+func ProcessNextWorkItem(name string, queue workqueue.RateLimitingInterface, handler SyncHandler) bool {
+	obj, shutdown := queue.Get()
+	if shutdown {
+		return false
+	}
+
+	//done obj
+	defer queue.Done(obj)
+
+	key, ok := obj.(string)
+	if !ok {
+		queue.Forget(obj)
+		log.Errorf("%s expected string in workqueue but got %#v", name, obj)
+		return true
+	}
+
+	// handler
+	start := time.Now()
+	err := handler(context.Background(), key)
+	metrics.ReconcileDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	if err == nil {
+		metrics.ReconcileTotal.WithLabelValues(name, "success").Inc()
+		queue.Forget(obj)
+		return true
+	}
+
+	//requeue after delay
+	if requeue, ok := err.(RequeueError); ok {
+		metrics.ReconcileTotal.WithLabelValues(name, "requeue").Inc()
+		queue.Forget(obj)
+		queue.AddAfter(obj, requeue.RequeueAfter())
+		return true
+	}
+
+	metrics.ReconcileTotal.WithLabelValues(name, "error").Inc()
+	metrics.ReconcileErrors.WithLabelValues(name).Inc()
+
+	//retry with backoff, capped by the rate limiter
+	if retries := queue.NumRequeues(obj); retries < MaxRetries {
+		log.Warnf("%s sync %s error %s, retry", name, key, err.Error())
+	} else {
+		log.Errorf("%s sync %s failed %d times: %s, retry with backoff", name, key, retries, err.Error())
+	}
+	queue.AddRateLimited(obj)
+	return true
+}
@@ -0,0 +1,44 @@
+package controller
+
+import (
+	"github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned/scheme"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+//event source component
+const EventComponent = "kubegames-operator"
+
+//event reasons
+const (
+	ReasonNamespaceCreated = "NamespaceCreated"
+	ReasonConfigMapCreated = "ConfigMapCreated"
+	ReasonConfigMapUpdated = "ConfigMapUpdated"
+	ReasonConfigMapDeleted = "ConfigMapDeleted"
+	ReasonGameIDConflict   = "GameIDConflict"
+	ReasonConfigReloaded   = "ConfigReloaded"
+	ReasonPodCreated       = "PodCreated"
+	ReasonPodReady         = "PodReady"
+	ReasonPodFailed        = "PodFailed"
+	ReasonPodRemoved       = "PodRemoved"
+	ReasonDrainRequested   = "DrainRequested"
+	ReasonDrainRefused     = "DrainRefused"
+	ReasonDrainTimeout     = "DrainTimeout"
+	ReasonPodDeleted       = "PodDeleted"
+	ReasonFinalizerRemoved = "FinalizerRemoved"
+	ReasonScaledUp         = "ScaledUp"
+	ReasonScaledDown       = "ScaledDown"
+	ReasonRoomPlaced       = "RoomPlaced"
+	ReasonRoomUnplaceable  = "RoomUnplaceable"
+	ReasonRoomDeleted      = "RoomDeleted"
+	ReasonSyncFailed       = "SyncFailed"
+)
+
+//new event recorder, the games scheme knows both games and rooms
+func NewRecorder(kubeclientset kubernetes.Interface) record.EventRecorder {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeclientset.CoreV1().Events("")})
+	return eventBroadcaster.NewRecorder(scheme.Scheme, corev1.EventSource{Component: EventComponent})
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+//expectations not observed in time are dropped, so a lost watch event can not block a key forever
+const ExpectationsTimeout = time.Minute * 5
+
+type (
+	//creations and deletions a controller issued but has not observed through the informer yet
+	Expectations struct {
+		mu    sync.Mutex
+		items map[string]*expectation
+	}
+
+	expectation struct {
+		add       int64
+		del       int64
+		timestamp time.Time
+	}
+)
+
+//new expectations
+func NewExpectations() *Expectations {
+	return &Expectations{items: make(map[string]*expectation)}
+}
+
+//expect creations of key, added to the creations still expected
+func (e *Expectations) ExpectCreations(key string, adds int) {
+	e.raise(key, adds, 0)
+}
+
+//expect deletions of key, added to the deletions still expected
+func (e *Expectations) ExpectDeletions(key string, dels int) {
+	e.raise(key, 0, dels)
+}
+
+//a creation of key was observed, or will never happen
+func (e *Expectations) CreationObserved(key string) {
+	e.lower(key, 1, 0)
+}
+
+//a deletion of key was observed, or will never happen
+func (e *Expectations) DeletionObserved(key string) {
+	e.lower(key, 0, 1)
+}
+
+//all expectations of key are observed or expired
+func (e *Expectations) Satisfied(key string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	item, ok := e.items[key]
+	if !ok {
+		return true
+	}
+	if item.add <= 0 && item.del <= 0 {
+		return true
+	}
+	return time.Since(item.timestamp) > ExpectationsTimeout
+}
+
+//delete expectations of key
+func (e *Expectations) Delete(key string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.items, key)
+}
+
+func (e *Expectations) raise(key string, adds, dels int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	//expired expectations start over
+	item, ok := e.items[key]
+	if !ok || time.Since(item.timestamp) > ExpectationsTimeout {
+		item = &expectation{}
+		e.items[key] = item
+	}
+
+	//events observed twice must not cancel new expectations
+	if item.add < 0 {
+		item.add = 0
+	}
+	if item.del < 0 {
+		item.del = 0
+	}
+	item.add += int64(adds)
+	item.del += int64(dels)
+	item.timestamp = time.Now()
+}
+
+func (e *Expectations) lower(key string, adds, dels int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if item, ok := e.items[key]; ok {
+		item.add -= int64(adds)
+		item.del -= int64(dels)
+	}
+}
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExpectations(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(e *Expectations, key string)
+		satisfied bool
+	}{
+		{
+			name:      "no expectations",
+			run:       func(e *Expectations, key string) {},
+			satisfied: true,
+		},
+		{
+			name: "creations pending",
+			run: func(e *Expectations, key string) {
+				e.ExpectCreations(key, 2)
+				e.CreationObserved(key)
+			},
+			satisfied: false,
+		},
+		{
+			name: "creations observed",
+			run: func(e *Expectations, key string) {
+				e.ExpectCreations(key, 2)
+				e.CreationObserved(key)
+				e.CreationObserved(key)
+			},
+			satisfied: true,
+		},
+		{
+			name: "raise adds to pending",
+			run: func(e *Expectations, key string) {
+				e.ExpectCreations(key, 1)
+				e.ExpectCreations(key, 1)
+				e.CreationObserved(key)
+			},
+			satisfied: false,
+		},
+		{
+			name: "deletions pending",
+			run: func(e *Expectations, key string) {
+				e.ExpectCreations(key, 1)
+				e.ExpectDeletions(key, 1)
+				e.CreationObserved(key)
+			},
+			satisfied: false,
+		},
+		{
+			name: "events observed twice do not cancel new expectations",
+			run: func(e *Expectations, key string) {
+				e.ExpectDeletions(key, 1)
+				e.DeletionObserved(key)
+				e.DeletionObserved(key)
+				e.ExpectDeletions(key, 1)
+			},
+			satisfied: false,
+		},
+		{
+			name: "expired",
+			run: func(e *Expectations, key string) {
+				e.ExpectCreations(key, 1)
+				e.items[key].timestamp = time.Now().Add(-ExpectationsTimeout - time.Second)
+			},
+			satisfied: true,
+		},
+		{
+			name: "raise after expiry starts over",
+			run: func(e *Expectations, key string) {
+				e.ExpectCreations(key, 3)
+				e.items[key].timestamp = time.Now().Add(-ExpectationsTimeout - time.Second)
+				e.ExpectCreations(key, 1)
+				e.CreationObserved(key)
+			},
+			satisfied: true,
+		},
+		{
+			name: "deleted",
+			run: func(e *Expectations, key string) {
+				e.ExpectCreations(key, 1)
+				e.Delete(key)
+			},
+			satisfied: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			e := NewExpectations()
+			test.run(e, "default/game")
+			if satisfied := e.Satisfied("default/game"); satisfied != test.satisfied {
+				t.Errorf("satisfied = %v, want %v", satisfied, test.satisfied)
+			}
+		})
+	}
+}
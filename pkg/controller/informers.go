@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	gamesclientset "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned"
+	gamesfactory "github.com/kubegames/kubegames-operator/pkg/client/game/informers/externalversions"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+)
+
+//resync period of shared informers
+const ResyncPeriod = time.Second * 15
+
+//shared informer factories of the controllers
+type Informers struct {
+	//watched namespace, all namespaces if empty
+	Namespace string
+	//objects managed by kubegames
+	Kube informers.SharedInformerFactory
+	//cluster scoped namespaces, unfiltered
+	Namespaces informers.SharedInformerFactory
+	//games and rooms
+	Games gamesfactory.SharedInformerFactory
+}
+
+//new informers of namespace, all namespaces if namespace is empty, the namespaces factory may be shared
+func NewInformers(kubeclientset kubernetes.Interface, gamesclientset gamesclientset.Interface, namespace string, namespaces informers.SharedInformerFactory) *Informers {
+	return &Informers{
+		Namespace: namespace,
+		Kube: informers.NewSharedInformerFactoryWithOptions(kubeclientset, ResyncPeriod,
+			informers.WithNamespace(namespace),
+			informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+				options.LabelSelector = labels.Set{tools.LabelsController: tools.LabelsControllerValue}.String()
+			}),
+		),
+		Namespaces: namespaces,
+		Games: gamesfactory.NewSharedInformerFactoryWithOptions(gamesclientset, ResyncPeriod,
+			gamesfactory.WithNamespace(namespace),
+		),
+	}
+}
+
+//new namespaces factory
+func NewNamespaceInformerFactory(kubeclientset kubernetes.Interface) informers.SharedInformerFactory {
+	return informers.NewSharedInformerFactory(kubeclientset, ResyncPeriod)
+}
+
+//name of a queue of the controllers of the watched namespace, queue metrics of namespaces do not collide
+func (i *Informers) QueueName(name string) string {
+	if len(i.Namespace) <= 0 {
+		return name
+	}
+	return fmt.Sprintf("%s-%s", name, i.Namespace)
+}
+
+//start the informers requested so far
+func (i *Informers) Start(stopCh <-chan struct{}) {
+	i.Kube.Start(stopCh)
+	i.Namespaces.Start(stopCh)
+	i.Games.Start(stopCh)
+}
@@ -0,0 +1,43 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+)
+
+//workers are stuck when keys wait in the queue this long without one being processed
+const StallTimeout = time.Minute * 5
+
+//progress of the workers of a queue
+type Progress struct {
+	queue workqueue.RateLimitingInterface
+	//unix nano of the last processed key
+	last int64
+}
+
+//new progress of queue
+func NewProgress(queue workqueue.RateLimitingInterface) *Progress {
+	return &Progress{queue: queue, last: time.Now().UnixNano()}
+}
+
+//a key was processed
+func (p *Progress) Observe() {
+	atomic.StoreInt64(&p.last, time.Now().UnixNano())
+}
+
+//liveness check, fails when keys are waiting and no worker processed one within StallTimeout
+func (p *Progress) Check(req *http.Request) error {
+	depth := p.queue.Len()
+	if depth <= 0 {
+		return nil
+	}
+	since := time.Since(time.Unix(0, atomic.LoadInt64(&p.last)))
+	if since > StallTimeout {
+		return fmt.Errorf("%d keys queued, no key processed for %s", depth, since.Round(time.Second))
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package convert
 import (
 	v1 "k8s.io/api/admission/v1"
 	"k8s.io/api/admission/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -81,6 +82,11 @@ func ConvertAdmissionResponseToV1beta1(r *v1.AdmissionResponse) *v1beta1.Admissi
 }
 
 func ToV1AdmissionResponse(err error) *v1.AdmissionResponse {
+	//api errors carry the reason and causes
+	if status, ok := err.(errors.APIStatus); ok {
+		result := status.Status()
+		return &v1.AdmissionResponse{Result: &result}
+	}
 	return &v1.AdmissionResponse{
 		Result: &metav1.Status{
 			Message: err.Error(),
@@ -0,0 +1,180 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubegames/kubegames-operator/app/game/types"
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	"github.com/kubegames/kubegames-operator/pkg/controller"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	//autoscaler poll interval
+	autoscaleInterval = time.Second * 15
+	//players rpc timeout
+	playersTimeout = time.Second * 5
+	//default target utilization percent
+	defaultTargetUtilization = 80
+	//default scale up cooldown
+	defaultScaleUpCooldown = time.Second * 30
+	//default scale down cooldown
+	defaultScaleDownCooldown = time.Minute * 5
+)
+
+//run autoscaler for all games
+func (c *Game) runAutoscaler() {
+	games, err := c.informer.Lister().List(labels.Everything())
+	if err != nil {
+		log.Errorf("list games error %s", err.Error())
+		return
+	}
+
+	for _, game := range games {
+		if game.Spec.Autoscaling == nil || game.ObjectMeta.DeletionTimestamp.IsZero() == false {
+			continue
+		}
+
+		if err := c.autoscaleGames(context.Background(), game); err != nil {
+			log.Errorf("autoscale games %s/%s error %s", game.Namespace, game.Name, err.Error())
+		}
+	}
+}
+
+//poll game pods players and adjust replicas
+func (c *Game) autoscaleGames(ctx context.Context, game *gamesv1.Game) error {
+	autoscaling := game.Spec.Autoscaling
+
+	//get players of ready pods
+	var players, capacity, reported uint32
+	names := make([]string, 0, len(game.Status.Pods))
+	for name, pod := range game.Status.Pods {
+		if pod != nil && pod.Ready {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	//ask pods in parallel, one tick takes one timeout at most
+	resps := make([]*types.PlayersResponse, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			pod := game.Status.Pods[name]
+
+			callctx, cancel := context.WithTimeout(ctx, playersTimeout)
+			defer cancel()
+			resp, err := c.playersCall(callctx, fmt.Sprintf("%s:%d", pod.PodIP, pod.Port), game.Spec.GameID)
+			if err != nil {
+				log.Errorf("get pod %s players error %s", name, err.Error())
+				return
+			}
+			resps[i] = resp
+		}(i, name)
+	}
+	wg.Wait()
+
+	for _, resp := range resps {
+		if resp == nil {
+			continue
+		}
+		players += resp.Players
+		capacity += resp.Capacity
+		reported++
+	}
+
+	//bounds
+	minReplicas := autoscaling.MinReplicas
+	if minReplicas == 0 {
+		minReplicas = 1
+	}
+	maxReplicas := autoscaling.MaxReplicas
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+
+	//desired replicas
+	desired := game.Spec.Replicas
+	if reported > 0 && capacity > 0 {
+		target := autoscaling.TargetUtilization
+		if target == 0 {
+			target = defaultTargetUtilization
+		}
+		perPod := float64(capacity) / float64(reported) * float64(target) / 100
+		desired = uint32(math.Ceil(float64(players+autoscaling.Buffer) / perPod))
+	}
+	if desired < minReplicas {
+		desired = minReplicas
+	}
+	if desired > maxReplicas {
+		desired = maxReplicas
+	}
+
+	//record players
+	if err := tools.UpdateGameStatus(ctx, c.gamesclientset, game, func(game *gamesv1.Game) {
+		game.Status.Players = players
+		game.Status.Capacity = capacity
+	}); err != nil {
+		return err
+	}
+
+	if desired == game.Spec.Replicas {
+		return nil
+	}
+
+	//players of the pods not reported are unknown, scaling down could drop them
+	if desired < game.Spec.Replicas && int(reported) < len(names) {
+		log.Warnf("autoscale games %s/%s skip scale down, %d/%d pods reported players", game.Namespace, game.Name, reported, len(names))
+		return nil
+	}
+
+	//cooldown
+	cooldown := defaultScaleDownCooldown
+	if autoscaling.ScaleDownCooldownSeconds > 0 {
+		cooldown = time.Duration(autoscaling.ScaleDownCooldownSeconds) * time.Second
+	}
+	if desired > game.Spec.Replicas {
+		cooldown = defaultScaleUpCooldown
+		if autoscaling.ScaleUpCooldownSeconds > 0 {
+			cooldown = time.Duration(autoscaling.ScaleUpCooldownSeconds) * time.Second
+		}
+	}
+	if game.Status.LastScaleTime != nil && time.Since(game.Status.LastScaleTime.Time) < cooldown {
+		log.Tracef("autoscale games %s/%s in cooldown", game.Namespace, game.Name)
+		return nil
+	}
+
+	//scale
+	scale, err := c.gamesclientset.KubegamesV1().Games(game.Namespace).GetScale(ctx, game.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	scale.Spec.Replicas = int32(desired)
+	if _, err := c.gamesclientset.KubegamesV1().Games(game.Namespace).UpdateScale(ctx, game.Name, scale, metav1.UpdateOptions{}); err != nil {
+		return err
+	}
+
+	reason := controller.ReasonScaledUp
+	if desired < game.Spec.Replicas {
+		reason = controller.ReasonScaledDown
+	}
+	c.recorder.Eventf(game, corev1.EventTypeNormal, reason, "scaled replicas from %d to %d, players %d capacity %d", game.Spec.Replicas, desired, players, capacity)
+	log.Infof("autoscale games %s/%s replicas %d -> %d players %d capacity %d", game.Namespace, game.Name, game.Spec.Replicas, desired, players, capacity)
+
+	//record scale time
+	now := metav1.Now()
+	return tools.UpdateGameStatus(ctx, c.gamesclientset, game, func(game *gamesv1.Game) {
+		game.Status.LastScaleTime = &now
+	})
+}
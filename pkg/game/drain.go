@@ -0,0 +1,135 @@
+package game
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	"github.com/kubegames/kubegames-operator/pkg/controller"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//default poll interval of pods refused to stop
+const defaultDrainPollInterval = time.Second * 15
+
+//poll interval of pods refused to stop
+func drainPollInterval(game *gamesv1.Game) time.Duration {
+	if game.Spec.Drain.PollIntervalSeconds > 0 {
+		return time.Duration(game.Spec.Drain.PollIntervalSeconds) * time.Second
+	}
+	return defaultDrainPollInterval
+}
+
+//time a pod may refuse to stop, 0 without timeout
+func drainTimeout(game *gamesv1.Game) time.Duration {
+	return time.Duration(game.Spec.Drain.TimeoutSeconds) * time.Second
+}
+
+//pod was asked to stop
+func isDraining(game *gamesv1.Game, podname string) bool {
+	status := game.Status.Pods[podname]
+	return status != nil && status.DrainStartTime != nil
+}
+
+//record the drain start of ready pods in status, returns the drain start of every pod
+func (c *Game) markDraining(ctx context.Context, game *gamesv1.Game, pods []*corev1.Pod) (map[string]time.Time, error) {
+	now := metav1.Now()
+	starts := make(map[string]time.Time, len(pods))
+	marks := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		if status := game.Status.Pods[pod.Name]; status != nil && status.DrainStartTime != nil {
+			starts[pod.Name] = status.DrainStartTime.Time
+			continue
+		}
+		starts[pod.Name] = now.Time
+
+		//only ready pods are asked to stop
+		if tools.IsPodReady(pod) {
+			marks = append(marks, pod.Name)
+		}
+	}
+
+	if len(marks) <= 0 {
+		return starts, nil
+	}
+
+	err := tools.UpdateGameStatus(ctx, c.gamesclientset, game, func(game *gamesv1.Game) {
+		if len(game.Status.Pods) <= 0 {
+			game.Status.Pods = make(map[string]*gamesv1.PodStatus)
+		}
+		for _, name := range marks {
+			status := game.Status.Pods[name]
+			if status == nil {
+				status = &gamesv1.PodStatus{Name: name}
+				game.Status.Pods[name] = status
+			}
+			if status.DrainStartTime == nil {
+				status.DrainStartTime = &now
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return starts, nil
+}
+
+//clear the drain start of pods no longer chosen to stop
+func (c *Game) clearDraining(ctx context.Context, game *gamesv1.Game, pods []*corev1.Pod) error {
+	clears := make([]string, 0)
+	for _, pod := range pods {
+		if isDraining(game, pod.Name) {
+			clears = append(clears, pod.Name)
+		}
+	}
+
+	if len(clears) <= 0 {
+		return nil
+	}
+
+	if err := tools.UpdateGameStatus(ctx, c.gamesclientset, game, func(game *gamesv1.Game) {
+		for _, name := range clears {
+			if status := game.Status.Pods[name]; status != nil {
+				status.DrainStartTime = nil
+			}
+		}
+	}); err != nil {
+		return err
+	}
+
+	log.Tracef("game %s/%s pods %v no longer draining", game.Namespace, game.Name, clears)
+	return nil
+}
+
+//pod refused to stop, returns a waitDrainError until the pod may be deleted
+func (c *Game) drainRefused(game *gamesv1.Game, pod *corev1.Pod, start time.Time) error {
+	poll := drainPollInterval(game)
+	timeout := drainTimeout(game)
+	elapsed := time.Since(start)
+
+	//no timeout or before the deadline, ask again by the deadline at the latest
+	if timeout <= 0 || elapsed < timeout {
+		log.Tracef("wait delete pod %s", pod.Name)
+		c.recorder.Eventf(game, corev1.EventTypeWarning, controller.ReasonDrainRefused, "pod %s refused to stop, retry later", pod.Name)
+
+		after := poll
+		if timeout > 0 && timeout-elapsed < after {
+			after = timeout - elapsed
+		}
+		return &waitDrainError{message: fmt.Sprintf("wait delete pod %s", pod.Name), after: after}
+	}
+
+	//keep asking
+	if game.Spec.Drain.Policy != gamesv1.DrainPolicyForce {
+		c.recorder.Eventf(game, corev1.EventTypeWarning, controller.ReasonDrainTimeout, "pod %s refused to stop for %s, wait by policy", pod.Name, elapsed.Round(time.Second))
+		return &waitDrainError{message: fmt.Sprintf("wait delete pod %s, drain timeout exceeded", pod.Name), after: poll}
+	}
+
+	log.Warnf("force delete pod %s/%s refused to stop for %s", pod.Namespace, pod.Name, elapsed.Round(time.Second))
+	c.recorder.Eventf(game, corev1.EventTypeWarning, controller.ReasonDrainTimeout, "pod %s refused to stop for %s, force deleting", pod.Name, elapsed.Round(time.Second))
+	return nil
+}
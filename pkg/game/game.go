@@ -2,7 +2,11 @@ package game
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	gameservice "github.com/kubegames/kubegames-operator/app/game"
@@ -10,25 +14,56 @@ import (
 	"github.com/kubegames/kubegames-operator/internal/pkg/log"
 	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
 	gamesclientset "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned"
-	"github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned/scheme"
-	gamesscheme "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned/scheme"
-	factory "github.com/kubegames/kubegames-operator/pkg/client/game/informers/externalversions"
 	informers "github.com/kubegames/kubegames-operator/pkg/client/game/informers/externalversions/game/v1"
+	"github.com/kubegames/kubegames-operator/pkg/controller"
+	"github.com/kubegames/kubegames-operator/pkg/metrics"
 	"github.com/kubegames/kubegames-operator/pkg/tools"
 	"google.golang.org/grpc"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
-	"k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/integer"
 )
 
+const (
+	//max pods created or deleted by one sync
+	burstReplicas = 500
+	//delete and reload config rpc timeout
+	callTimeout = time.Second * 10
+)
+
+//pod refused to stop, retry later
+type waitDrainError struct {
+	message string
+	after   time.Duration
+}
+
+func (e *waitDrainError) Error() string {
+	return e.message
+}
+
+func (e *waitDrainError) RequeueAfter() time.Duration {
+	return e.after
+}
+
+//config map of the gameid is owned by another game
+type conflictError struct {
+	message string
+}
+
+func (e *conflictError) Error() string {
+	return e.message
+}
+
 // Game is the game implementation for Game resources
 type Game struct {
 	// kubeclientset is a standard kubernetes clientset
@@ -37,33 +72,36 @@ type Game struct {
 	gamesclientset gamesclientset.Interface
 	//informer
 	informer informers.GameInformer
+	//pod informer
+	podInformer coreinformers.PodInformer
+	//config map informer
+	configMapInformer coreinformers.ConfigMapInformer
+	//namespace informer
+	namespaceInformer coreinformers.NamespaceInformer
 	//queue
-	workqueue workqueue.DelayingInterface
-	//factory
-	factory factory.SharedInformerFactory
+	workqueue workqueue.RateLimitingInterface
+	//progress of the workers
+	progress *controller.Progress
+	//event recorder
+	recorder record.EventRecorder
+	//pod creations and deletions not observed yet
+	expectations *controller.Expectations
 }
 
-// returns a new game
-func NewGame(kubeclientset kubernetes.Interface, config *rest.Config) *Game {
-
-	//new game client set
-	gamesclientset, err := gamesclientset.NewForConfig(config)
-	if err != nil {
-		panic(err)
-	}
-
-	//new game factory
-	factory := factory.NewSharedInformerFactory(gamesclientset, time.Second*15)
-
-	runtime.Must(gamesscheme.AddToScheme(scheme.Scheme))
-
+// returns a new game, the shared informers are started by the caller
+func NewGame(kubeclientset kubernetes.Interface, gamesclientset gamesclientset.Interface, informers *controller.Informers) *Game {
 	game := &Game{
-		kubeclientset:  kubeclientset,
-		gamesclientset: gamesclientset,
-		workqueue:      workqueue.NewDelayingQueue(),
-		informer:       factory.Kubegames().V1().Games(),
-		factory:        factory,
+		kubeclientset:     kubeclientset,
+		gamesclientset:    gamesclientset,
+		workqueue:         controller.NewQueue(informers.QueueName("games")),
+		informer:          informers.Games.Kubegames().V1().Games(),
+		podInformer:       informers.Kube.Core().V1().Pods(),
+		configMapInformer: informers.Kube.Core().V1().ConfigMaps(),
+		namespaceInformer: informers.Namespaces.Core().V1().Namespaces(),
+		recorder:          controller.NewRecorder(kubeclientset),
+		expectations:      controller.NewExpectations(),
 	}
+	game.progress = controller.NewProgress(game.workqueue)
 
 	//listen game change event
 	game.informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -97,59 +135,126 @@ func NewGame(kubeclientset kubernetes.Interface, config *rest.Config) *Game {
 		},
 	})
 
+	//listen game pod change event
+	game.podInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			pod := obj.(*corev1.Pod)
+			key, ok := podGameKey(pod)
+			if !ok {
+				return
+			}
+			if pod.ObjectMeta.DeletionTimestamp.IsZero() == false {
+				game.expectations.DeletionObserved(key)
+			} else {
+				game.expectations.CreationObserved(key)
+			}
+			game.workqueue.Add(key)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldpod := old.(*corev1.Pod)
+			newpod := new.(*corev1.Pod)
+			if oldpod.ResourceVersion == newpod.ResourceVersion {
+				return
+			}
+			key, ok := podGameKey(newpod)
+			if !ok {
+				return
+			}
+			if oldpod.ObjectMeta.DeletionTimestamp.IsZero() && newpod.ObjectMeta.DeletionTimestamp.IsZero() == false {
+				game.expectations.DeletionObserved(key)
+			}
+			game.workqueue.Add(key)
+		},
+		DeleteFunc: func(obj interface{}) {
+			pod, ok := obj.(*corev1.Pod)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("delete Func error unexpected object %#v", obj)
+					return
+				}
+				if pod, ok = tombstone.Obj.(*corev1.Pod); !ok {
+					log.Errorf("delete Func error unexpected tombstone object %#v", tombstone.Obj)
+					return
+				}
+			}
+			key, ok := podGameKey(pod)
+			if !ok {
+				return
+			}
+			//deletion already observed by the update
+			if pod.ObjectMeta.DeletionTimestamp.IsZero() {
+				game.expectations.DeletionObserved(key)
+			}
+			game.workqueue.Add(key)
+		},
+	})
+
 	return game
 }
 
+//key of the game that owns pod
+func podGameKey(pod *corev1.Pod) (string, bool) {
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		if ref.Kind != "Game" {
+			return "", false
+		}
+		return pod.Namespace + "/" + ref.Name, true
+	}
+	if name, ok := pod.Labels[tools.LabelsGameName]; ok {
+		return pod.Namespace + "/" + name, true
+	}
+	return "", false
+}
+
 //run
 func (c *Game) Run(threadiness int, stopCh <-chan struct{}) {
 	defer runtime.HandleCrash()
 	defer c.workqueue.ShutDown()
 
-	//start factory
-	go c.factory.Start(stopCh)
-
-	if ok := cache.WaitForCacheSync(stopCh, c.informer.Informer().HasSynced); !ok {
+	if ok := cache.WaitForCacheSync(stopCh, c.HasSynced); !ok {
 		panic("failed to wait for caches to sync")
 	}
 
+	//keys queued while the caches synced are not a stall
+	c.progress.Observe()
+
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
 	}
 
+	//start autoscaler
+	go wait.Until(c.runAutoscaler, autoscaleInterval, stopCh)
+
+	//start orphan sweeper
+	go wait.Until(c.runSweeper, sweepInterval, stopCh)
+
+	//game pods metrics
+	metrics.RegisterGames(c.informer.Lister())
+
 	log.Infoln("game controller start")
 	<-stopCh
 	log.Infoln("game controller end")
 	return
 }
 
-func (c *Game) runWorker() {
-	for c.processNextWorkItem() {
-	}
+//progress of the workers
+func (c *Game) Progress() *controller.Progress {
+	return c.progress
 }
 
-// processNextWorkItem
-func (c *Game) processNextWorkItem() bool {
-	obj, shutdown := c.workqueue.Get()
-	if shutdown {
-		return false
-	}
-
-	//done obj
-	c.workqueue.Done(obj)
-
-	key, ok := obj.(string)
-	if !ok {
-		log.Errorf("expected string in workqueue but got %#v", obj)
-		return true
-	}
+//informer caches synced
+func (c *Game) HasSynced() bool {
+	return c.informer.Informer().HasSynced() &&
+		c.podInformer.Informer().HasSynced() &&
+		c.configMapInformer.Informer().HasSynced() &&
+		c.namespaceInformer.Informer().HasSynced()
+}
 
-	// handler
-	if err := c.syncHandler(context.Background(), key); err != nil {
-		c.workqueue.AddAfter(obj, time.Second*15)
-		return false
+func (c *Game) runWorker() {
+	for controller.ProcessNextWorkItem("games", c.workqueue, c.syncHandler) {
+		c.progress.Observe()
 	}
-
-	return true
 }
 
 // handler
@@ -164,17 +269,10 @@ func (c *Game) syncHandler(ctx context.Context, key string) error {
 	// get games
 	game, err := c.informer.Lister().Games(namespace).Get(name)
 	if err != nil {
-		// delete
+		// delete, owned pods and config map are garbage collected
 		if errors.IsNotFound(err) {
-			//delete config map
-			if err := c.kubeclientset.CoreV1().ConfigMaps(namespace).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
-				if errors.IsNotFound(err) == false {
-					log.Errorf("delete configmap error %s", err.Error())
-				}
-				return err
-			}
-
 			log.Tracef("game delete %s/%s", namespace, name)
+			c.expectations.Delete(key)
 			return nil
 		}
 
@@ -186,14 +284,24 @@ func (c *Game) syncHandler(ctx context.Context, key string) error {
 	if game.ObjectMeta.DeletionTimestamp.IsZero() {
 		log.Tracef("add or update games %s/%s", namespace, name)
 
-		if err := c.updateGames(ctx, game); err != nil {
+		err := c.updateGames(ctx, key, game)
+		if err != nil {
 			log.Errorf("update games %s/%s error %s", namespace, name, err.Error())
+		}
+
+		//update status
+		if err := c.updateGameStatus(ctx, game, err); err != nil {
+			log.Errorf("update games %s/%s status error %s", namespace, name, err.Error())
+			return err
+		}
+
+		if err != nil {
 			return err
 		}
 	} else {
 		log.Tracef("delete games %s/%s", namespace, name)
 
-		if err := c.deleteGames(ctx, game); err != nil {
+		if err := c.deleteGames(ctx, key, game); err != nil {
 			log.Errorf("delete games %s/%s error %s", namespace, name, err.Error())
 			return err
 		}
@@ -203,52 +311,432 @@ func (c *Game) syncHandler(ctx context.Context, key string) error {
 	return nil
 }
 
-func (c *Game) deleteGames(ctx context.Context, game *gamesv1.Game) error {
+func (c *Game) deleteGames(ctx context.Context, key string, game *gamesv1.Game) error {
 	if tools.ContainsString(game.ObjectMeta.Finalizers, tools.Finalizer) {
 
 		//get pod
-		pods, err := c.kubeclientset.CoreV1().Pods(game.Namespace).List(ctx, metav1.ListOptions{
-			LabelSelector: labels.FormatLabels(map[string]string{tools.LabelsGameID: game.Spec.GameID}),
-		})
+		pods, err := c.listGamePods(game)
 		if err != nil {
 			log.Errorf("get pod list error %s", err.Error())
 			return err
 		}
 
 		//check pod items
-		if len(pods.Items) <= 0 {
+		if len(pods) <= 0 {
+			//delete config map
+			if err := c.deleteConfigMap(ctx, game, configMapName(game)); err != nil {
+				log.Errorf("delete configmap error %s", err.Error())
+				return err
+			}
+
 			//update games finalizer
-			game.ObjectMeta.Finalizers = tools.RemoveString(game.ObjectMeta.Finalizers, tools.Finalizer)
-			if _, err := c.gamesclientset.KubegamesV1().Games(game.Namespace).Update(ctx, game, metav1.UpdateOptions{}); err != nil {
+			newgame := game.DeepCopy()
+			newgame.ObjectMeta.Finalizers = tools.RemoveString(newgame.ObjectMeta.Finalizers, tools.Finalizer)
+			if _, err := c.gamesclientset.KubegamesV1().Games(game.Namespace).Update(ctx, newgame, metav1.UpdateOptions{}); err != nil {
 				log.Errorf("update games error %s", err.Error())
 				return err
 			}
+			c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonFinalizerRemoved, "all pods closed, removed finalizer %s", tools.Finalizer)
 			return nil
 		}
 
-		for _, pod := range pods.Items {
-			log.Tracef("reduce - game pod %s", pod.Name)
-
-			if err := c.reduceGamePod(ctx, &pod, game); err != nil {
-				log.Errorf("reduce - game pod %s error %s", pod.Name, err.Error())
+		//drain and delete in parallel
+		waitErr := &waitDrainError{message: "wait pod all close", after: drainPollInterval(game)}
+		if err := c.reduceGamePods(ctx, key, game, pods[:integer.IntMin(len(pods), burstReplicas)]); err != nil {
+			if wait, ok := err.(*waitDrainError); ok {
+				waitErr.after = wait.after
+			} else {
+				log.Errorf("reduce - game pods error %s", err.Error())
 			}
 		}
-		return fmt.Errorf("wait pod all close")
+
+		//update status
+		err = waitErr
+		if err := c.updateGameStatus(ctx, game, err); err != nil {
+			log.Errorf("update games %s/%s status error %s", game.Namespace, game.Name, err.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+func (c *Game) updateGames(ctx context.Context, key string, game *gamesv1.Game) error {
+	//check namespace and config
+	if err := c.prepareGames(ctx, game); err != nil {
+		return err
+	}
+
+	//get game pods
+	pods, err := c.listGamePods(game)
+	if err != nil {
+		log.Errorf("get pod list error %s", err.Error())
+		return err
+	}
+
+	//adopt pods created without owner
+	if err := c.adoptGamePods(ctx, game, pods); err != nil {
+		log.Errorf("adopt game pods error %s", err.Error())
+		return err
+	}
+
+	//current revision
+	revision := tools.Revision(game)
+
+	//label pods created before revisions running the current template
+	if err := c.labelGamePods(ctx, game, pods, revision); err != nil {
+		log.Errorf("label game pods error %s", err.Error())
+		return err
+	}
+
+	//classify pods
+	active := make([]*corev1.Pod, 0, len(pods))
+	updated := make([]*corev1.Pod, 0, len(pods))
+	old := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if pod.ObjectMeta.DeletionTimestamp.IsZero() == false {
+			continue
+		}
+		active = append(active, pod)
+		if tools.IsPodCurrent(pod, game) {
+			updated = append(updated, pod)
+		} else {
+			old = append(old, pod)
+		}
 	}
+
+	//hot reload config
+	reloadErr := c.reloadGamePods(ctx, game, updated)
+
+	//the cache has not seen the pods created or deleted by the last sync yet
+	if c.expectations.Satisfied(key) == false {
+		log.Tracef("game %s wait pod expectations", key)
+		return reloadErr
+	}
+
+	//rolling update or scale
+	if len(old) > 0 {
+		//only old pods are drained by the rolling update
+		if err := c.clearDraining(ctx, game, updated); err != nil {
+			log.Errorf("clear game pods draining error %s", err.Error())
+			return err
+		}
+		err = c.rollingUpdate(ctx, key, game, pods, active, updated, old)
+	} else {
+		err = c.scaleGames(ctx, key, game, pods, active)
+	}
+	if err != nil {
+		return err
+	}
+	return reloadErr
+}
+
+//scale game pods to replicas
+func (c *Game) scaleGames(ctx context.Context, key string, game *gamesv1.Game, pods, active []*corev1.Pod) error {
+	diff := len(active) - int(game.Spec.Replicas)
+
+	//pods no longer scaled down serve again
+	keep := active
+	var victims []*corev1.Pod
+	if diff > 0 {
+		ranked := c.rankPods(ctx, game, active)
+		victims, keep = ranked[:integer.IntMin(diff, burstReplicas)], ranked[integer.IntMin(diff, burstReplicas):]
+	}
+	if err := c.clearDraining(ctx, game, keep); err != nil {
+		log.Errorf("clear game pods draining error %s", err.Error())
+		return err
+	}
+
+	//pod +
+	if diff < 0 {
+		return c.increaseGamePods(ctx, key, game, tools.NextPodNames(game, pods, integer.IntMin(-diff, burstReplicas)))
+	}
+
+	//pod -, the least loaded
+	if diff > 0 {
+		return c.reduceGamePods(ctx, key, game, victims)
+	}
+
+	log.Tracef("game %s/%s spec == status", game.Namespace, game.Name)
 	return nil
 }
 
-func (c *Game) updateGames(ctx context.Context, game *gamesv1.Game) error {
-	//get create pod number
-	number := uint32(len(game.Status.Pods))
+//rolling update old pods to the current revision
+func (c *Game) rollingUpdate(ctx context.Context, key string, game *gamesv1.Game, pods, active, updated, old []*corev1.Pod) error {
+	maxSurge, maxUnavailable, err := tools.RollingUpdateBounds(game)
+	if err != nil {
+		log.Errorf("game %s/%s strategy error %s", game.Namespace, game.Name, err.Error())
+		return err
+	}
+
+	replicas := int(game.Spec.Replicas)
+
+	log.Tracef("rolling update game %s/%s updated %d old %d", game.Namespace, game.Name, len(updated), len(old))
+
+	//count ready pods
+	ready := 0
+	for _, pod := range active {
+		if tools.IsPodReady(pod) {
+			ready++
+		}
+	}
+	surge, removable := rollingBudget(replicas, maxSurge, maxUnavailable, len(active), len(updated), ready)
+
+	//surge new pods
+	if surge > 0 {
+		if err := c.increaseGamePods(ctx, key, game, tools.NextPodNames(game, pods, integer.IntMin(surge, burstReplicas))); err != nil {
+			log.Errorf("rolling update + game pods error %s", err.Error())
+			return err
+		}
+	}
+
+	//ready old pods that can go while keeping min available, not ready old pods can always go
+	victims := make([]*corev1.Pod, 0, len(old))
+	for _, pod := range c.rankPods(ctx, game, old) {
+		if tools.IsPodReady(pod) {
+			if removable <= 0 {
+				continue
+			}
+			removable--
+		}
+		victims = append(victims, pod)
+	}
 
-	if number == game.Spec.Replicas {
-		log.Tracef("game %s/%s spec == status", game.Namespace, game.Name)
+	if len(victims) <= 0 {
+		log.Tracef("rolling update wait game %s/%s pods ready", game.Namespace, game.Name)
 		return nil
 	}
 
+	if err := c.reduceGamePods(ctx, key, game, victims[:integer.IntMin(len(victims), burstReplicas)]); err != nil {
+		log.Errorf("rolling update - game pods error %s", err.Error())
+		return err
+	}
+	return nil
+}
+
+//pods to surge and ready old pods that can go in one rolling update step. surge stops at
+//replicas updated pods and replicas+maxSurge active pods, ready pods stay above replicas-maxUnavailable
+func rollingBudget(replicas, maxSurge, maxUnavailable, active, updated, ready int) (int, int) {
+	surge := integer.IntMax(integer.IntMin(replicas-updated, replicas+maxSurge-active), 0)
+	removable := integer.IntMax(integer.IntMax(ready-(replicas-maxUnavailable), active-(replicas+maxSurge)), 0)
+	return surge, removable
+}
+
+//pods in drain order, not ready pods first, then the pods already draining, then the pods with the fewest players
+func (c *Game) rankPods(ctx context.Context, game *gamesv1.Game, pods []*corev1.Pod) []*corev1.Pod {
+	//ask ready pods for players in parallel
+	loads := make([]podLoad, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		loads[i] = podLoad{pod: pod, ready: tools.IsPodReady(pod), draining: isDraining(game, pod.Name)}
+		if loads[i].ready == false {
+			continue
+		}
+
+		wg.Add(1)
+		go func(item *podLoad) {
+			defer wg.Done()
+			callctx, cancel := context.WithTimeout(ctx, playersTimeout)
+			defer cancel()
+			resp, err := c.playersCall(callctx, fmt.Sprintf("%s:%d", item.pod.Status.PodIP, game.Spec.Port), game.Spec.GameID)
+			if err == nil {
+				item.known = true
+				item.players = resp.Players
+			}
+		}(&loads[i])
+	}
+	wg.Wait()
+
+	sortPodLoads(game, loads)
+
+	ranked := make([]*corev1.Pod, 0, len(loads))
+	for _, item := range loads {
+		ranked = append(ranked, item.pod)
+	}
+	return ranked
+}
+
+//players of a pod to drain
+type podLoad struct {
+	pod      *corev1.Pod
+	ready    bool
+	draining bool
+	known    bool
+	players  uint32
+}
+
+//sort loads in drain order, pods that did not report players are kept as long as possible
+func sortPodLoads(game *gamesv1.Game, loads []podLoad) {
+	sort.Slice(loads, func(i, j int) bool {
+		if loads[i].ready != loads[j].ready {
+			return loads[j].ready
+		}
+		if loads[i].draining != loads[j].draining {
+			return loads[i].draining
+		}
+		if loads[i].known != loads[j].known {
+			return loads[i].known
+		}
+		if loads[i].players != loads[j].players {
+			return loads[i].players < loads[j].players
+		}
+		return tools.PodOrdinal(game, loads[i].pod.Name) > tools.PodOrdinal(game, loads[j].pod.Name)
+	})
+}
+
+//update game status after sync
+func (c *Game) updateGameStatus(ctx context.Context, game *gamesv1.Game, syncErr error) error {
+	_, waitDrain := syncErr.(*waitDrainError)
+	_, conflict := syncErr.(*conflictError)
+
+	//pods deleted while the operator was not watching are left in status by the pod controller
+	pods, err := c.listGamePods(game)
+	if err != nil {
+		log.Errorf("get pod list error %s", err.Error())
+		return err
+	}
+	exists := make(map[string]bool, len(pods))
+	for _, pod := range pods {
+		exists[pod.Name] = true
+	}
+
+	err = tools.UpdateGameStatus(ctx, c.gamesclientset, game, func(game *gamesv1.Game) {
+		game.Status.UpdateRevision = tools.Revision(game)
+
+		//prune pods gone
+		for name := range game.Status.Pods {
+			if exists[name] == false {
+				delete(game.Status.Pods, name)
+			}
+		}
+
+		//config map owned by game
+		if conflict == false && game.ObjectMeta.DeletionTimestamp.IsZero() {
+			game.Status.ConfigMap = game.Spec.GameID
+		}
+
+		//set pod counts and conditions
+		tools.SetGameStatus(game)
+
+		//draining
+		switch {
+		case game.ObjectMeta.DeletionTimestamp.IsZero() == false:
+			tools.SetGameCondition(game, gamesv1.GameDraining, metav1.ConditionTrue, "GameDeleting", "wait pod all close")
+		case waitDrain:
+			tools.SetGameCondition(game, gamesv1.GameDraining, metav1.ConditionTrue, "WaitPodDrain", syncErr.Error())
+		default:
+			tools.SetGameCondition(game, gamesv1.GameDraining, metav1.ConditionFalse, "NoDrain", "no pod is draining")
+		}
+
+		//degraded
+		failed := make([]string, 0)
+		for name, pod := range game.Status.Pods {
+			if pod != nil && pod.Phase == corev1.PodFailed {
+				failed = append(failed, name)
+			}
+		}
+		sort.Strings(failed)
+		switch {
+		case conflict:
+			tools.SetGameCondition(game, gamesv1.GameDegraded, metav1.ConditionTrue, "GameIDConflict", syncErr.Error())
+		case syncErr != nil && waitDrain == false && game.ObjectMeta.DeletionTimestamp.IsZero():
+			tools.SetGameCondition(game, gamesv1.GameDegraded, metav1.ConditionTrue, "SyncError", syncErr.Error())
+		case len(failed) > 0:
+			tools.SetGameCondition(game, gamesv1.GameDegraded, metav1.ConditionTrue, "PodFailed", fmt.Sprintf("pods %s failed", strings.Join(failed, ",")))
+		default:
+			tools.SetGameCondition(game, gamesv1.GameDegraded, metav1.ConditionFalse, "AsExpected", "game is synced")
+		}
+
+		//observed generation of the game synced
+		game.Status.ObservedGeneration = game.Generation
+	})
+	if err != nil && errors.IsNotFound(err) == false {
+		return err
+	}
+	return nil
+}
+
+//list the pods of game from cache, pods of other games with the same gameid are left out. the pods must not be modified
+func (c *Game) listGamePods(game *gamesv1.Game) ([]*corev1.Pod, error) {
+	pods, err := c.podInformer.Lister().Pods(game.Namespace).List(tools.GameSelector(game))
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]*corev1.Pod, 0, len(pods))
+	for _, pod := range pods {
+		if tools.IsGamePod(pod, game) {
+			owned = append(owned, pod)
+		}
+	}
+	return owned, nil
+}
+
+//set game as the controller owner of pods without one
+func (c *Game) adoptGamePods(ctx context.Context, game *gamesv1.Game, pods []*corev1.Pod) error {
+	for _, pod := range pods {
+		if metav1.GetControllerOf(pod) != nil || pod.ObjectMeta.DeletionTimestamp.IsZero() == false {
+			continue
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"ownerReferences": []metav1.OwnerReference{tools.GameOwnerReference(game)},
+				"uid":             pod.UID,
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.kubeclientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, k8stypes.StrategicMergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			//deleted, or adopted by another game with the same gameid
+			if errors.IsNotFound(err) || errors.IsInvalid(err) {
+				continue
+			}
+			return err
+		}
+		log.Tracef("adopt game pod %s/%s", pod.Namespace, pod.Name)
+	}
+	return nil
+}
+
+//pod without revision label runs the current template
+func isUnlabelledCurrent(pod *corev1.Pod, game *gamesv1.Game) bool {
+	_, ok := pod.Labels[tools.LabelsRevision]
+	return !ok && tools.MatchesTemplate(pod, game)
+}
+
+//set the revision label of pods running the current template without one
+func (c *Game) labelGamePods(ctx context.Context, game *gamesv1.Game, pods []*corev1.Pod, revision string) error {
+	for _, pod := range pods {
+		if pod.ObjectMeta.DeletionTimestamp.IsZero() == false || isUnlabelledCurrent(pod, game) == false {
+			continue
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"labels": map[string]string{tools.LabelsRevision: revision},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		if _, err := c.kubeclientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, k8stypes.MergePatchType, patch, metav1.PatchOptions{}); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		log.Tracef("label game pod %s/%s revision %s", pod.Namespace, pod.Name, revision)
+	}
+	return nil
+}
+
+//check game namespace and config
+func (c *Game) prepareGames(ctx context.Context, game *gamesv1.Game) error {
 	//chek game namespace
-	if _, err := c.kubeclientset.CoreV1().Namespaces().Get(ctx, game.Namespace, metav1.GetOptions{}); err != nil {
+	if _, err := c.namespaceInformer.Lister().Get(game.Namespace); err != nil {
 		if errors.IsNotFound(err) == false {
 			log.Errorf("get namespace error %s", err.Error())
 			return err
@@ -258,13 +746,26 @@ func (c *Game) updateGames(ctx context.Context, game *gamesv1.Game) error {
 		ns := &corev1.Namespace{}
 		ns.Name = game.Namespace
 		if _, err := c.kubeclientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
-			log.Errorf("create namespace error %s", err.Error())
+			if errors.IsAlreadyExists(err) == false {
+				log.Errorf("create namespace error %s", err.Error())
+				return err
+			}
+		} else {
+			c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonNamespaceCreated, "created namespace %s", game.Namespace)
+		}
+	}
+
+	//config map of the previous gameid
+	if name := configMapName(game); name != game.Spec.GameID {
+		if err := c.deleteConfigMap(ctx, game, name); err != nil {
+			log.Errorf("delete configmap error %s", err.Error())
 			return err
 		}
 	}
 
 	//check games config
-	if _, err := c.kubeclientset.CoreV1().ConfigMaps(game.Namespace).Get(ctx, game.Spec.GameID, metav1.GetOptions{}); err != nil {
+	cm, err := c.configMapInformer.Lister().ConfigMaps(game.Namespace).Get(game.Spec.GameID)
+	if err != nil {
 		if errors.IsNotFound(err) == false {
 			log.Errorf("get configmap error %s", err.Error())
 			return err
@@ -272,71 +773,240 @@ func (c *Game) updateGames(ctx context.Context, game *gamesv1.Game) error {
 
 		//create config map
 		if _, err := c.kubeclientset.CoreV1().ConfigMaps(game.Namespace).Create(ctx, tools.CreateConfigMap(game), metav1.CreateOptions{}); err != nil {
-			log.Errorf("create configmap error %s", err.Error())
-			return err
+			if errors.IsAlreadyExists(err) == false {
+				log.Errorf("create configmap error %s", err.Error())
+				return err
+			}
+
+			//cache is behind, or the config map is not managed by kubegames
+			existing, err := c.kubeclientset.CoreV1().ConfigMaps(game.Namespace).Get(ctx, game.Spec.GameID, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			if tools.IsOwnedBy(existing, game) == false {
+				err := &conflictError{message: fmt.Sprintf("configmap %s is not managed by game %s", game.Spec.GameID, game.Name)}
+				c.recorder.Event(game, corev1.EventTypeWarning, controller.ReasonGameIDConflict, err.Error())
+				return err
+			}
+			return nil
 		}
+		c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonConfigMapCreated, "created configmap %s", game.Spec.GameID)
+		return nil
 	}
 
-	//pod +
-	if number < game.Spec.Replicas {
-		//+ pod
-		podname := fmt.Sprintf("%s-%d", game.Spec.GameID, number)
+	//gameid is used by another game
+	if ref := metav1.GetControllerOf(cm); ref != nil && ref.UID != game.UID {
+		err := &conflictError{message: fmt.Sprintf("gameid %s is used by game %s", game.Spec.GameID, ref.Name)}
+		c.recorder.Event(game, corev1.EventTypeWarning, controller.ReasonGameIDConflict, err.Error())
+		return err
+	}
 
-		log.Tracef("increase + game pod %s", podname)
+	//sync config map, adopt config map created without owner
+	value, ok := cm.Data[tools.MountConfigName]
+	orphan := metav1.GetControllerOf(cm) == nil
+	if !ok || value != game.Spec.Config || orphan {
+		newcm := cm.DeepCopy()
+		if newcm.Data == nil {
+			newcm.Data = make(map[string]string)
+		}
+		newcm.Data[tools.MountConfigName] = game.Spec.Config
+		if orphan {
+			newcm.OwnerReferences = append(newcm.OwnerReferences, tools.GameOwnerReference(game))
+		}
 
-		//increase game pod
-		if err := c.increaseGamePod(ctx, number, podname, game); err != nil {
-			log.Errorf("increase + game pod error %s", err.Error())
+		if _, err := c.kubeclientset.CoreV1().ConfigMaps(game.Namespace).Update(ctx, newcm, metav1.UpdateOptions{}); err != nil {
+			log.Errorf("update configmap error %s", err.Error())
 			return err
 		}
+
+		c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonConfigMapUpdated, "updated configmap %s", cm.Name)
+		log.Tracef("update configmap %s/%s", game.Namespace, cm.Name)
 	}
+	return nil
+}
 
-	//pod -
-	if number > game.Spec.Replicas {
-		//- pod
-		podname := fmt.Sprintf("%s-%d", game.Spec.GameID, number-1)
+//name of the config map tracked in status
+func configMapName(game *gamesv1.Game) string {
+	if len(game.Status.ConfigMap) > 0 {
+		return game.Status.ConfigMap
+	}
+	return game.Spec.GameID
+}
+
+//delete config map owned by game
+func (c *Game) deleteConfigMap(ctx context.Context, game *gamesv1.Game, name string) error {
+	cm, err := c.configMapInformer.Lister().ConfigMaps(game.Namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	//not ours
+	if tools.IsOwnedBy(cm, game) == false {
+		return nil
+	}
 
-		//get pods
-		pod, err := c.kubeclientset.CoreV1().Pods(game.Namespace).Get(ctx, podname, metav1.GetOptions{})
+	if err := c.kubeclientset.CoreV1().ConfigMaps(game.Namespace).Delete(ctx, name, metav1.DeleteOptions{
+		Preconditions: metav1.NewUIDPreconditions(string(cm.UID)),
+	}); err != nil && errors.IsNotFound(err) == false {
+		return err
+	}
+
+	c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonConfigMapDeleted, "deleted configmap %s", name)
+	log.Tracef("delete configmap %s/%s", game.Namespace, name)
+	return nil
+}
+
+//send the new config to pods with a stale config hash
+func (c *Game) reloadGamePods(ctx context.Context, game *gamesv1.Game, pods []*corev1.Pod) error {
+	//pods are rolled instead
+	if game.Spec.DisableHotReload {
+		return nil
+	}
+
+	hash := tools.ConfigHash(game)
+
+	var lastErr error
+	for _, pod := range pods {
+		if pod.Annotations[tools.AnnotationsConfigHash] == hash || tools.IsPodReady(pod) == false {
+			continue
+		}
+
+		log.Tracef("reload game pod %s config", pod.Name)
+
+		//call server reload config
+		callctx, cancel := context.WithTimeout(ctx, callTimeout)
+		ok, err := c.reloadCall(callctx, fmt.Sprintf("%s:%d", pod.Status.PodIP, game.Spec.Port), game.Spec.GameID, game.Spec.Config)
+		cancel()
 		if err != nil {
-			log.Errorf("get pod %s/%s error %s", game.Namespace, podname, err.Error())
-			return err
+			lastErr = err
+			continue
+		}
+		if ok == false {
+			lastErr = fmt.Errorf("pod %s refuse reload config", pod.Name)
+			c.recorder.Event(game, corev1.EventTypeWarning, controller.ReasonSyncFailed, lastErr.Error())
+			log.Errorf("reload game pod error %s", lastErr.Error())
+			continue
 		}
 
-		log.Tracef("reduce - game pod %s", podname)
+		//record config hash
+		patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, tools.AnnotationsConfigHash, hash)
+		if _, err := c.kubeclientset.CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, k8stypes.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+			log.Errorf("patch pod %s config hash error %s", pod.Name, err.Error())
+			lastErr = err
+			continue
+		}
+		c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonConfigReloaded, "reloaded config of pod %s", pod.Name)
+	}
+	return lastErr
+}
 
-		//reduce game pod
-		if err := c.reduceGamePod(ctx, pod, game); err != nil {
-			log.Errorf("reduce - game pod error %s", err.Error())
-			return err
+//create game pods in slow start batches
+func (c *Game) increaseGamePods(ctx context.Context, key string, game *gamesv1.Game, podnames []string) error {
+	log.Tracef("increase + game %s pods %v", key, podnames)
+
+	c.expectations.ExpectCreations(key, len(podnames))
+	successes, err := controller.SlowStartBatch(len(podnames), controller.SlowStartInitialBatchSize, func(index int) error {
+		created, err := c.increaseGamePod(ctx, podnames[index], game)
+		if err == nil && created == false {
+			c.expectations.CreationObserved(key)
 		}
+		return err
+	})
+
+	//failed and skipped creations will never be observed
+	for i := successes; i < len(podnames); i++ {
+		c.expectations.CreationObserved(key)
+	}
+
+	if err != nil {
+		log.Errorf("increase + game pods error %s", err.Error())
+		return err
 	}
 	return nil
 }
 
-//increase game pod +
-func (c *Game) increaseGamePod(ctx context.Context, number uint32, podname string, game *gamesv1.Game) error {
+//drain and delete game pods in parallel
+func (c *Game) reduceGamePods(ctx context.Context, key string, game *gamesv1.Game, pods []*corev1.Pod) error {
+	//record drain start
+	starts, err := c.markDraining(ctx, game, pods)
+	if err != nil {
+		log.Errorf("mark game pods draining error %s", err.Error())
+		return err
+	}
+
+	c.expectations.ExpectDeletions(key, len(pods))
+
+	errs := make([]error, len(pods))
+	var wg sync.WaitGroup
+	for i, pod := range pods {
+		wg.Add(1)
+		go func(i int, pod *corev1.Pod) {
+			defer wg.Done()
+			log.Tracef("reduce - game pod %s", pod.Name)
+
+			deleted, err := c.reduceGamePod(ctx, pod, game, starts[pod.Name])
+			if deleted == false {
+				c.expectations.DeletionObserved(key)
+			}
+			errs[i] = err
+		}(i, pod)
+	}
+	wg.Wait()
+
+	//pods refused to stop are retried by the earliest deadline
+	var waitErr *waitDrainError
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if wait, ok := err.(*waitDrainError); ok {
+			if waitErr == nil || wait.after < waitErr.after {
+				waitErr = wait
+			}
+			continue
+		}
+		log.Errorf("reduce - game pods error %s", err.Error())
+		return err
+	}
+	if waitErr != nil {
+		return waitErr
+	}
+	return nil
+}
+
+//increase game pod +, false if the pod already exists
+func (c *Game) increaseGamePod(ctx context.Context, podname string, game *gamesv1.Game) (bool, error) {
 	//create pod
 	if _, err := c.kubeclientset.CoreV1().Pods(game.Namespace).Create(ctx, tools.CreatePod(podname, game), metav1.CreateOptions{}); err != nil {
 		if errors.IsAlreadyExists(err) == false {
 			log.Errorf("create pod error %s", err.Error())
-			return err
+			c.recorder.Eventf(game, corev1.EventTypeWarning, controller.ReasonSyncFailed, "create pod %s error %s", podname, err.Error())
+			return false, err
 		}
+		return false, nil
 	}
-	return nil
+	c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonPodCreated, "created pod %s", podname)
+	return true, nil
 }
 
-//reduce game pod -
-func (c *Game) reduceGamePod(ctx context.Context, pod *corev1.Pod, game *gamesv1.Game) error {
+//reduce game pod -, false if the pod was not deleted by this call
+func (c *Game) reduceGamePod(ctx context.Context, pod *corev1.Pod, game *gamesv1.Game, start time.Time) (bool, error) {
 	//check pod is running
 	if pod.Status.Phase == corev1.PodRunning && pod.ObjectMeta.DeletionTimestamp.IsZero() {
 		for _, condition := range pod.Status.Conditions {
 			if condition.Type == corev1.ContainersReady && condition.Status == corev1.ConditionTrue {
 				//call server delete
-				ok, err := c.deleteCall(ctx, fmt.Sprintf("%s:%d", pod.Status.PodIP, game.Spec.Port), game.Spec.GameID)
+				c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonDrainRequested, "requested pod %s to drain", pod.Name)
+				callctx, cancel := context.WithTimeout(ctx, callTimeout)
+				ok, err := c.deleteCall(callctx, fmt.Sprintf("%s:%d", pod.Status.PodIP, game.Spec.Port), game.Spec.GameID)
+				cancel()
 				if err == nil && ok == false {
-					log.Tracef("wait delete pod %s", pod.Name)
-					return fmt.Errorf("wait delete pod %s", pod.Name)
+					if err := c.drainRefused(game, pod, start); err != nil {
+						return false, err
+					}
 				}
 				break
 			}
@@ -348,7 +1018,7 @@ func (c *Game) reduceGamePod(ctx context.Context, pod *corev1.Pod, game *gamesv1
 	}); err != nil {
 		if errors.IsNotFound(err) == false {
 			log.Errorf("get event %s", err.Error())
-			return err
+			return false, err
 		}
 	}
 
@@ -356,11 +1026,13 @@ func (c *Game) reduceGamePod(ctx context.Context, pod *corev1.Pod, game *gamesv1
 	if err := c.kubeclientset.CoreV1().Pods(game.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
 		if errors.IsNotFound(err) == false {
 			log.Errorf("delete pod error %s", err.Error())
-			return err
+			return false, err
 		}
+		return false, nil
 	}
+	c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonPodDeleted, "deleted pod %s", pod.Name)
 
-	return nil
+	return true, nil
 }
 
 //defalt call rpc
@@ -379,3 +1051,37 @@ func (c *Game) deleteCall(ctx context.Context, address string, gameID string) (b
 	}
 	return resp.Success, nil
 }
+
+//reload config call rpc
+func (c *Game) reloadCall(ctx context.Context, address string, gameID string, config string) (bool, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		log.Errorf("did not connect: %v", err)
+		return false, err
+	}
+	defer conn.Close()
+	client := gameservice.NewGameServiceClient(conn)
+	resp, err := client.ReloadConfig(ctx, &types.ReloadConfigRequest{GameID: gameID, Config: config})
+	if err != nil {
+		log.Errorf("grpc reload config call error %s", err.Error())
+		return false, err
+	}
+	return resp.Success, nil
+}
+
+//players call rpc
+func (c *Game) playersCall(ctx context.Context, address string, gameID string) (*types.PlayersResponse, error) {
+	conn, err := grpc.Dial(address, grpc.WithInsecure())
+	if err != nil {
+		log.Errorf("did not connect: %v", err)
+		return nil, err
+	}
+	defer conn.Close()
+	client := gameservice.NewGameServiceClient(conn)
+	resp, err := client.Players(ctx, &types.PlayersRequest{GameID: gameID})
+	if err != nil {
+		log.Errorf("grpc players call error %s", err.Error())
+		return nil, err
+	}
+	return resp, nil
+}
@@ -0,0 +1,97 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRollingBudget(t *testing.T) {
+	tests := []struct {
+		name                               string
+		replicas, maxSurge, maxUnavailable int
+		active, updated, ready             int
+		surge, removable                   int
+	}{
+		{name: "start", replicas: 4, maxSurge: 1, maxUnavailable: 1, active: 4, updated: 0, ready: 4, surge: 1, removable: 1},
+		{name: "surged pod not ready", replicas: 4, maxSurge: 1, maxUnavailable: 1, active: 5, updated: 1, ready: 4, surge: 0, removable: 1},
+		{name: "min available reached", replicas: 4, maxSurge: 1, maxUnavailable: 1, active: 4, updated: 1, ready: 3, surge: 1, removable: 0},
+		{name: "no surge", replicas: 4, maxSurge: 0, maxUnavailable: 2, active: 4, updated: 0, ready: 4, surge: 0, removable: 2},
+		{name: "all updated", replicas: 4, maxSurge: 1, maxUnavailable: 1, active: 5, updated: 4, ready: 5, surge: 0, removable: 2},
+		{name: "over surge bound", replicas: 4, maxSurge: 1, maxUnavailable: 0, active: 7, updated: 2, ready: 2, surge: 0, removable: 2},
+		{name: "scaled up while rolling", replicas: 8, maxSurge: 2, maxUnavailable: 2, active: 4, updated: 0, ready: 4, surge: 6, removable: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			surge, removable := rollingBudget(test.replicas, test.maxSurge, test.maxUnavailable, test.active, test.updated, test.ready)
+			if surge != test.surge || removable != test.removable {
+				t.Errorf("budget = %d, %d, want %d, %d", surge, removable, test.surge, test.removable)
+			}
+		})
+	}
+}
+
+func TestSortPodLoads(t *testing.T) {
+	game := &gamesv1.Game{Spec: gamesv1.GameSpec{GameID: "poker"}}
+	pod := func(name string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	tests := []struct {
+		name  string
+		loads []podLoad
+		order []string
+	}{
+		{
+			name: "not ready first",
+			loads: []podLoad{
+				{pod: pod("poker-0"), ready: true, known: true, players: 0},
+				{pod: pod("poker-1"), ready: false},
+			},
+			order: []string{"poker-1", "poker-0"},
+		},
+		{
+			name: "draining before the fewest players",
+			loads: []podLoad{
+				{pod: pod("poker-0"), ready: true, known: true, players: 0},
+				{pod: pod("poker-1"), ready: true, draining: true, known: true, players: 9},
+			},
+			order: []string{"poker-1", "poker-0"},
+		},
+		{
+			name: "fewest players, unknown players last",
+			loads: []podLoad{
+				{pod: pod("poker-0"), ready: true, known: false},
+				{pod: pod("poker-1"), ready: true, known: true, players: 5},
+				{pod: pod("poker-2"), ready: true, known: true, players: 1},
+			},
+			order: []string{"poker-2", "poker-1", "poker-0"},
+		},
+		{
+			name: "ties go to the highest ordinal",
+			loads: []podLoad{
+				{pod: pod("poker-2"), ready: true, known: true, players: 1},
+				{pod: pod("poker-10"), ready: true, known: true, players: 1},
+				{pod: pod("poker-3"), ready: true, known: true, players: 1},
+			},
+			order: []string{"poker-10", "poker-3", "poker-2"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sortPodLoads(game, test.loads)
+			order := make([]string, 0, len(test.loads))
+			for _, load := range test.loads {
+				order = append(order, load.pod.Name)
+			}
+			if strings.Join(order, ",") != strings.Join(test.order, ",") {
+				t.Errorf("order = %v, want %v", order, test.order)
+			}
+		})
+	}
+}
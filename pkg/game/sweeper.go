@@ -0,0 +1,97 @@
+package game
+
+import (
+	"context"
+	"time"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	//orphan sweeper interval
+	sweepInterval = time.Minute * 5
+	//objects younger than the grace period are skipped, the game cache may lag behind
+	sweepGracePeriod = time.Minute
+)
+
+//delete pods and config maps whose game no longer exists
+func (c *Game) runSweeper() {
+	ctx := context.Background()
+
+	//sweep pods
+	pods, err := c.podInformer.Lister().List(labels.Everything())
+	if err != nil {
+		log.Errorf("sweeper list pods error %s", err.Error())
+		return
+	}
+	for _, pod := range pods {
+		if c.isOrphan(pod) == false {
+			continue
+		}
+
+		log.Warnf("sweeper delete orphan pod %s/%s", pod.Namespace, pod.Name)
+		if err := c.kubeclientset.CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(pod.UID)),
+		}); err != nil && errors.IsNotFound(err) == false {
+			log.Errorf("sweeper delete pod %s/%s error %s", pod.Namespace, pod.Name, err.Error())
+		}
+	}
+
+	//sweep config maps
+	cms, err := c.configMapInformer.Lister().List(labels.Everything())
+	if err != nil {
+		log.Errorf("sweeper list configmaps error %s", err.Error())
+		return
+	}
+	for _, cm := range cms {
+		if c.isOrphan(cm) == false {
+			continue
+		}
+
+		log.Warnf("sweeper delete orphan configmap %s/%s", cm.Namespace, cm.Name)
+		if err := c.kubeclientset.CoreV1().ConfigMaps(cm.Namespace).Delete(ctx, cm.Name, metav1.DeleteOptions{
+			Preconditions: metav1.NewUIDPreconditions(string(cm.UID)),
+		}); err != nil && errors.IsNotFound(err) == false {
+			log.Errorf("sweeper delete configmap %s/%s error %s", cm.Namespace, cm.Name, err.Error())
+		}
+	}
+}
+
+//object has no live owning game
+func (c *Game) isOrphan(obj metav1.Object) bool {
+	if obj.GetDeletionTimestamp() != nil || time.Since(obj.GetCreationTimestamp().Time) < sweepGracePeriod {
+		return false
+	}
+
+	//owned by controller reference
+	if ref := metav1.GetControllerOf(obj); ref != nil {
+		if ref.Kind != "Game" {
+			return false
+		}
+		game, err := c.informer.Lister().Games(obj.GetNamespace()).Get(ref.Name)
+		if err != nil {
+			return errors.IsNotFound(err)
+		}
+		return game.UID != ref.UID
+	}
+
+	//not adopted yet, look up the game by gameid
+	gameid, ok := obj.GetLabels()[tools.LabelsGameID]
+	if !ok {
+		return false
+	}
+	games, err := c.informer.Lister().Games(obj.GetNamespace()).List(labels.Everything())
+	if err != nil {
+		return false
+	}
+	for _, game := range games {
+		if game.Spec.GameID == gameid {
+			return false
+		}
+	}
+	return true
+}
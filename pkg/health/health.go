@@ -0,0 +1,127 @@
+package health
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+)
+
+type (
+	//named check, compatible with leaderelection.HealthzAdaptor
+	Checker interface {
+		Name() string
+		Check(req *http.Request) error
+	}
+
+	//check of func
+	checkFunc struct {
+		name string
+		f    func(req *http.Request) error
+	}
+
+	//status of func, reported but never fails
+	statusFunc struct {
+		name string
+		f    func() string
+	}
+
+	//health serves liveness and readiness checks
+	Health struct {
+		mu      sync.RWMutex
+		healthz []Checker
+		readyz  []Checker
+		status  []statusFunc
+	}
+)
+
+//new named check
+func NamedCheck(name string, f func(req *http.Request) error) Checker {
+	return &checkFunc{name: name, f: f}
+}
+
+func (c *checkFunc) Name() string {
+	return c.name
+}
+
+func (c *checkFunc) Check(req *http.Request) error {
+	return c.f(req)
+}
+
+//new health
+func New() *Health {
+	return &Health{
+		healthz: make([]Checker, 0),
+		readyz:  make([]Checker, 0),
+		status:  make([]statusFunc, 0),
+	}
+}
+
+//add liveness checks
+func (h *Health) AddHealthz(checks ...Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.healthz = append(h.healthz, checks...)
+}
+
+//add readiness checks
+func (h *Health) AddReadyz(checks ...Checker) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.readyz = append(h.readyz, checks...)
+}
+
+//add status reported by readiness
+func (h *Health) AddStatus(name string, f func() string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.status = append(h.status, statusFunc{name: name, f: f})
+}
+
+//health http handler
+func (h *Health) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.RLock()
+		checks := h.healthz
+		h.mu.RUnlock()
+		serve(w, r, "healthz", checks, nil)
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		h.mu.RLock()
+		checks, status := h.readyz, h.status
+		h.mu.RUnlock()
+		serve(w, r, "readyz", checks, status)
+	})
+	return mux
+}
+
+//run checks, fail the request if any check fails
+func serve(w http.ResponseWriter, r *http.Request, name string, checks []Checker, status []statusFunc) {
+	var out bytes.Buffer
+	failed := false
+	for _, check := range checks {
+		if err := check.Check(r); err != nil {
+			log.Warnf("%s check %s failed: %s", name, check.Name(), err.Error())
+			fmt.Fprintf(&out, "[-]%s failed: %s\n", check.Name(), err.Error())
+			failed = true
+			continue
+		}
+		fmt.Fprintf(&out, "[+]%s ok\n", check.Name())
+	}
+	for _, item := range status {
+		fmt.Fprintf(&out, "[*]%s %s\n", item.name, item.f())
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if failed {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(&out, "%s check failed\n", name)
+	} else {
+		fmt.Fprintf(&out, "%s check passed\n", name)
+	}
+	w.Write(out.Bytes())
+}
@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	listers "github.com/kubegames/kubegames-operator/pkg/client/game/listers/game/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+var (
+	gameDesiredPods = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "game", "desired_pods"),
+		"Number of pods desired by game spec.",
+		[]string{"namespace", "game", "gameid"}, nil,
+	)
+
+	gameReadyPods = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "game", "ready_pods"),
+		"Number of ready pods in game status.",
+		[]string{"namespace", "game", "gameid"}, nil,
+	)
+
+	gameDrainingPods = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "game", "draining_pods"),
+		"Number of game pods waiting to be drained.",
+		[]string{"namespace", "game", "gameid"}, nil,
+	)
+)
+
+//game collector reads game status from the informer caches on every scrape
+type gameCollector struct {
+	mu      sync.RWMutex
+	listers []listers.GameLister
+}
+
+var games = &gameCollector{listers: make([]listers.GameLister, 0)}
+
+func init() {
+	prometheus.MustRegister(games)
+}
+
+//register game gauges of the lister
+func RegisterGames(lister listers.GameLister) {
+	games.mu.Lock()
+	defer games.mu.Unlock()
+	games.listers = append(games.listers, lister)
+}
+
+func (c *gameCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- gameDesiredPods
+	ch <- gameReadyPods
+	ch <- gameDrainingPods
+}
+
+func (c *gameCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	for _, lister := range c.listers {
+		games, err := lister.List(labels.Everything())
+		if err != nil {
+			log.Errorf("list games metrics error %s", err.Error())
+			continue
+		}
+
+		for _, game := range games {
+			ch <- prometheus.MustNewConstMetric(gameDesiredPods, prometheus.GaugeValue, float64(game.Spec.Replicas), game.Namespace, game.Name, game.Spec.GameID)
+			ch <- prometheus.MustNewConstMetric(gameReadyPods, prometheus.GaugeValue, float64(game.Status.ReadyReplicas), game.Namespace, game.Name, game.Spec.GameID)
+			ch <- prometheus.MustNewConstMetric(gameDrainingPods, prometheus.GaugeValue, float64(drainingPods(game)), game.Namespace, game.Name, game.Spec.GameID)
+		}
+	}
+}
+
+//pods asked to stop, or all pods of a deleting game
+func drainingPods(game *gamesv1.Game) uint32 {
+	if game.ObjectMeta.DeletionTimestamp.IsZero() == false {
+		return game.Status.Replicas
+	}
+	var draining uint32
+	for _, pod := range game.Status.Pods {
+		if pod != nil && pod.DrainStartTime != nil {
+			draining++
+		}
+	}
+	return draining
+}
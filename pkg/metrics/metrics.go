@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+//metrics namespace
+const namespace = "kubegames"
+
+var (
+	//reconcile count of every controller by result
+	ReconcileTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "controller",
+		Name:      "reconcile_total",
+		Help:      "Total number of reconciliations per controller and result.",
+	}, []string{"controller", "result"})
+
+	//reconcile error count of every controller
+	ReconcileErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "controller",
+		Name:      "reconcile_errors_total",
+		Help:      "Total number of reconciliation errors per controller.",
+	}, []string{"controller"})
+
+	//reconcile duration of every controller
+	ReconcileDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "controller",
+		Name:      "reconcile_duration_seconds",
+		Help:      "Duration of reconciliations per controller.",
+		Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+	}, []string{"controller"})
+
+	//admission request count by webhook path
+	AdmissionTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "admission",
+		Name:      "requests_total",
+		Help:      "Total number of admission requests per webhook path.",
+	}, []string{"path"})
+
+	//admission denied count by webhook path
+	AdmissionDenied = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "admission",
+		Name:      "denied_total",
+		Help:      "Total number of denied admission requests per webhook path.",
+	}, []string{"path"})
+
+	//admission latency by webhook path
+	AdmissionDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "admission",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of admission requests per webhook path.",
+		Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+	}, []string{"path"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		ReconcileTotal,
+		ReconcileErrors,
+		ReconcileDuration,
+		AdmissionTotal,
+		AdmissionDenied,
+		AdmissionDuration,
+	)
+}
+
+//metrics http handler
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
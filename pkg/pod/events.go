@@ -0,0 +1,93 @@
+package pod
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/kubernetes"
+)
+
+//events of a pod are listed at most once per interval, pods resync within it
+const eventsRefreshInterval = time.Second * 30
+
+//notes of the pod events listed last
+type podEvents struct {
+	notes  []string
+	listed time.Time
+	podUID string
+}
+
+//events of the kubegames pods, listed per pod instead of caching the events of all pods
+type eventsCache struct {
+	kubeclientset kubernetes.Interface
+	mu            sync.Mutex
+	pods          map[string]*podEvents
+}
+
+func newEventsCache(kubeclientset kubernetes.Interface) *eventsCache {
+	return &eventsCache{
+		kubeclientset: kubeclientset,
+		pods:          make(map[string]*podEvents),
+	}
+}
+
+//notes of the pod events in order, listed again when the last list is older than the interval
+func (e *eventsCache) notes(ctx context.Context, pod *corev1.Pod) ([]string, error) {
+	key := tools.IndexKey(pod.Namespace, pod.Name)
+
+	e.mu.Lock()
+	cached, ok := e.pods[key]
+	e.mu.Unlock()
+	if ok && cached.podUID == string(pod.UID) && time.Since(cached.listed) < eventsRefreshInterval {
+		return cached.notes, nil
+	}
+
+	list, err := e.kubeclientset.EventsV1().Events(pod.Namespace).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{"regarding.kind": "Pod", "regarding.name": pod.Name}.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	//add events in order
+	events := list.Items
+	sort.Slice(events, func(i, j int) bool {
+		return eventTime(&events[i]).Before(eventTime(&events[j]))
+	})
+	notes := make([]string, 0, len(events))
+	for i := range events {
+		notes = append(notes, events[i].Note)
+	}
+
+	e.mu.Lock()
+	e.pods[key] = &podEvents{notes: notes, listed: time.Now(), podUID: string(pod.UID)}
+	e.mu.Unlock()
+	return notes, nil
+}
+
+//forget the events of a deleted pod
+func (e *eventsCache) forget(namespace, name string) {
+	e.mu.Lock()
+	delete(e.pods, tools.IndexKey(namespace, name))
+	e.mu.Unlock()
+}
+
+//time the event last happened
+func eventTime(event *eventsv1.Event) time.Time {
+	switch {
+	case event.Series != nil:
+		return event.Series.LastObservedTime.Time
+	case event.EventTime.IsZero() == false:
+		return event.EventTime.Time
+	case event.DeprecatedLastTimestamp.IsZero() == false:
+		return event.DeprecatedLastTimestamp.Time
+	}
+	return event.CreationTimestamp.Time
+}
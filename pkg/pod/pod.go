@@ -2,24 +2,23 @@ package pod
 
 import (
 	"context"
-	"strings"
 	"time"
 
 	"github.com/kubegames/kubegames-operator/internal/pkg/log"
 	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
 	gamesclientset "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned"
+	gameinformers "github.com/kubegames/kubegames-operator/pkg/client/game/informers/externalversions/game/v1"
+	"github.com/kubegames/kubegames-operator/pkg/controller"
 	"github.com/kubegames/kubegames-operator/pkg/tools"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
-	"k8s.io/client-go/informers"
 	podsv1 "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 )
 
@@ -30,47 +29,38 @@ type (
 		kubeclientset kubernetes.Interface
 		//informer
 		informer podsv1.PodInformer
-		//factory
-		factory informers.SharedInformerFactory
+		//game informer
+		gameInformer gameinformers.GameInformer
+		//events of the pods
+		events *eventsCache
 		//queue
-		workqueue workqueue.DelayingInterface
+		workqueue workqueue.RateLimitingInterface
+		//progress of the workers
+		progress *controller.Progress
 		// gamesclientset is a clientset for our own API group
 		gamesclientset gamesclientset.Interface
+		//event recorder
+		recorder record.EventRecorder
 	}
 )
 
-//new pod
-func NewPod(kubeclientset kubernetes.Interface, config *rest.Config) *Pod {
-	//new game client set
-	gamesclientset, err := gamesclientset.NewForConfig(config)
-	if err != nil {
-		panic(err)
-	}
-
-	//new factory
-	factory := informers.NewSharedInformerFactory(kubeclientset, time.Second*15)
-
+//new pod, the shared informers are started by the caller
+func NewPod(kubeclientset kubernetes.Interface, gamesclientset gamesclientset.Interface, informers *controller.Informers) *Pod {
 	//new pod
 	pod := &Pod{
 		kubeclientset:  kubeclientset,
-		informer:       factory.Core().V1().Pods(),
-		factory:        factory,
-		workqueue:      workqueue.NewDelayingQueue(),
+		informer:       informers.Kube.Core().V1().Pods(),
+		gameInformer:   informers.Games.Kubegames().V1().Games(),
+		events:         newEventsCache(kubeclientset),
+		workqueue:      controller.NewQueue(informers.QueueName("pods")),
 		gamesclientset: gamesclientset,
+		recorder:       controller.NewRecorder(kubeclientset),
 	}
+	pod.progress = controller.NewProgress(pod.workqueue)
 
 	//listen game change event
 	pod.informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc: func(obj interface{}) {
-			objpod := obj.(*corev1.Pod)
-			value, ok := objpod.Labels[tools.LabelsController]
-			if !ok {
-				return
-			}
-			if value != tools.LabelsControllerValue {
-				return
-			}
-
 			key, err := cache.MetaNamespaceKeyFunc(obj)
 			if err != nil {
 				log.Errorf("add error %s", err.Error())
@@ -81,14 +71,6 @@ func NewPod(kubeclientset kubernetes.Interface, config *rest.Config) *Pod {
 		UpdateFunc: func(old, new interface{}) {
 			oldpod := old.(*corev1.Pod)
 			newpod := new.(*corev1.Pod)
-			value, ok := newpod.Labels[tools.LabelsController]
-			if !ok {
-				return
-			}
-			if value != tools.LabelsControllerValue {
-				return
-			}
-
 			if oldpod.ResourceVersion != newpod.ResourceVersion {
 				key, err := cache.MetaNamespaceKeyFunc(new)
 				if err != nil {
@@ -99,14 +81,6 @@ func NewPod(kubeclientset kubernetes.Interface, config *rest.Config) *Pod {
 			}
 		},
 		DeleteFunc: func(obj interface{}) {
-			objpod := obj.(*corev1.Pod)
-			value, ok := objpod.Labels[tools.LabelsController]
-			if !ok {
-				return
-			}
-			if value != tools.LabelsControllerValue {
-				return
-			}
 			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
 			if err != nil {
 				log.Errorf("delete Func error %s", err.Error())
@@ -115,6 +89,12 @@ func NewPod(kubeclientset kubernetes.Interface, config *rest.Config) *Pod {
 			pod.workqueue.Add(key)
 		},
 	})
+
+	//index games by status pods and gameid
+	if err := tools.AddGameIndexers(pod.gameInformer.Informer()); err != nil {
+		panic(err)
+	}
+
 	return pod
 }
 
@@ -123,12 +103,13 @@ func (c *Pod) Run(threadiness int, stopCh <-chan struct{}) {
 	defer runtime.HandleCrash()
 	defer c.workqueue.ShutDown()
 
-	go c.factory.Start(stopCh)
-
-	if ok := cache.WaitForCacheSync(stopCh, c.informer.Informer().HasSynced); !ok {
+	if ok := cache.WaitForCacheSync(stopCh, c.HasSynced); !ok {
 		panic("failed to wait for caches to sync")
 	}
 
+	//keys queued while the caches synced are not a stall
+	c.progress.Observe()
+
 	for i := 0; i < threadiness; i++ {
 		go wait.Until(c.runWorker, time.Second, stopCh)
 	}
@@ -139,35 +120,20 @@ func (c *Pod) Run(threadiness int, stopCh <-chan struct{}) {
 	return
 }
 
-func (c *Pod) runWorker() {
-	for c.processNextWorkItem() {
-	}
+//progress of the workers
+func (c *Pod) Progress() *controller.Progress {
+	return c.progress
 }
 
-// processNextWorkItem
-func (c *Pod) processNextWorkItem() bool {
-	obj, shutdown := c.workqueue.Get()
-	if shutdown {
-		return false
-	}
-
-	//done obj
-	c.workqueue.Done(obj)
-
-	//to key
-	key, ok := obj.(string)
-	if !ok {
-		log.Errorf("expected string in workqueue but got %#v", obj)
-		return true
-	}
+//informer caches synced
+func (c *Pod) HasSynced() bool {
+	return c.informer.Informer().HasSynced() && c.gameInformer.Informer().HasSynced()
+}
 
-	// handler
-	if err := c.syncHandler(context.Background(), key); err != nil {
-		c.workqueue.AddAfter(obj, time.Second*15)
-		return false
+func (c *Pod) runWorker() {
+	for controller.ProcessNextWorkItem("pods", c.workqueue, c.syncHandler) {
+		c.progress.Observe()
 	}
-
-	return true
 }
 
 // handler
@@ -191,14 +157,9 @@ func (c *Pod) syncHandler(ctx context.Context, key string) error {
 		return c.deletePods(ctx, namespace, name)
 	}
 
-	if pod.Status.Phase != corev1.PodRunning || pod.ObjectMeta.DeletionTimestamp.IsZero() == false {
-		return nil
-	}
-
-	for _, condition := range pod.Status.Conditions {
-		if condition.Type == corev1.ContainersReady && condition.Status != corev1.ConditionTrue {
-			return nil
-		}
+	//terminating pod no longer serves
+	if pod.ObjectMeta.DeletionTimestamp.IsZero() == false {
+		return c.deletePods(ctx, namespace, name)
 	}
 
 	log.Tracef("pod add or update rooms %s/%s phase %s", namespace, name, pod.Status.Phase)
@@ -214,94 +175,157 @@ func (c *Pod) syncHandler(ctx context.Context, key string) error {
 
 func (c *Pod) updatePods(ctx context.Context, pod *corev1.Pod) error {
 	log.Tracef("notice pod %s open rooms", pod.Name)
-	if gameid, ok := pod.Labels[tools.LabelsGameID]; ok {
-		//game not found
-		game, err := c.gamesclientset.KubegamesV1().Games(pod.Namespace).Get(ctx, gameid, v1.GetOptions{})
-		if err != nil {
-			log.Errorf("get game %s/%s error %s", pod.Namespace, gameid, err.Error())
-			return err
-		}
 
+	//get game
+	game, err := c.getGame(pod)
+	if err != nil {
+		log.Errorf("get pod %s/%s game error %s", pod.Namespace, pod.Name, err.Error())
+		return err
+	}
+
+	//game not found, the orphan pod is garbage collected
+	if game == nil {
+		log.Tracef("pod %s/%s game not found", pod.Namespace, pod.Name)
+		return nil
+	}
+
+	//create pod
+	podstatus := &gamesv1.PodStatus{
+		Name:     pod.Name,
+		HostIP:   pod.Status.HostIP,
+		PodIP:    pod.Status.PodIP,
+		Port:     game.Spec.Port,
+		Phase:    pod.Status.Phase,
+		Ready:    tools.IsPodReady(pod),
+		Revision: pod.Labels[tools.LabelsRevision],
+	}
+
+	//get events
+	events, err := c.events.notes(ctx, pod)
+	if err != nil {
+		log.Errorf("get pod %s/%s events error %s", pod.Namespace, pod.Name, err.Error())
+		return err
+	}
+	podstatus.Events = events
+
+	//update
+	if err := tools.UpdateGameStatus(ctx, c.gamesclientset, game, func(game *gamesv1.Game) {
 		//init game status pods
 		if len(game.Status.Pods) <= 0 {
 			game.Status.Pods = make(map[string]*gamesv1.PodStatus)
 		}
 
-		//create pod
-		podstatus := &gamesv1.PodStatus{
-			Name:   pod.Name,
-			HostIP: pod.Status.HostIP,
-			PodIP:  pod.Status.PodIP,
-			Port:   game.Spec.Port,
-			Phase:  pod.Status.Phase,
-			Events: make([]string, 0),
+		//keep drain start
+		if prev := game.Status.Pods[pod.Name]; prev != nil {
+			podstatus.DrainStartTime = prev.DrainStartTime
 		}
 
-		//get events
-		events, err := c.kubeclientset.EventsV1().Events(pod.Namespace).List(ctx, v1.ListOptions{
-			FieldSelector: fields.Set{"regarding.name": pod.Name}.String(),
-		})
-		if err != nil {
-			log.Errorf("get event %s", err.Error())
-			return err
+		//set pod status
+		game.Status.Pods[pod.Name] = podstatus
 
-		}
+		//set pod counts and conditions
+		tools.SetGameStatus(game)
+	}); err != nil {
+		log.Errorf("update games %s/%s status error %s", game.Namespace, game.Name, err.Error())
+		return err
+	}
 
-		//add events
-		for _, event := range events.Items {
-			podstatus.Events = append(podstatus.Events, event.Note)
-		}
+	//pod state changed
+	prev := game.Status.Pods[pod.Name]
+	if podstatus.Ready && (prev == nil || prev.Ready == false) {
+		c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonPodReady, "pod %s is ready on %s", pod.Name, pod.Status.PodIP)
+	}
+	if podstatus.Phase == corev1.PodFailed && (prev == nil || prev.Phase != corev1.PodFailed) {
+		c.recorder.Eventf(game, corev1.EventTypeWarning, controller.ReasonPodFailed, "pod %s failed: %s", pod.Name, pod.Status.Message)
+	}
 
-		//set pod status
-		game.Status.Pods[pod.Name] = podstatus
+	log.Tracef("update games %s/%s status", game.Name, game.Namespace)
+	return nil
+}
 
-		//set update
-		game.Status.UpdateAt = time.Now().String()
+func (c *Pod) deletePods(ctx context.Context, namespace, name string) error {
+	log.Tracef("pod delete %s/%s", namespace, name)
+	c.events.forget(namespace, name)
+
+	//games with the pod in status
+	objs, err := c.gameInformer.Informer().GetIndexer().ByIndex(tools.GamePodIndex, tools.IndexKey(namespace, name))
+	if err != nil {
+		log.Errorf("get pod %s/%s games error %s", namespace, name, err.Error())
+		return err
+	}
+
+	for _, obj := range objs {
+		game := obj.(*gamesv1.Game)
+
+		//check game is delete
+		if game.ObjectMeta.DeletionTimestamp.IsZero() == false {
+			continue
+		}
 
 		//update
-		if _, err := c.gamesclientset.KubegamesV1().Games(game.Namespace).Update(ctx, game, v1.UpdateOptions{}); err != nil {
-			log.Errorf("update games %s/%s status error %s", game.Namespace, game.Name, err.Error())
+		if err := tools.UpdateGameStatus(ctx, c.gamesclientset, game, func(game *gamesv1.Game) {
+			//delete
+			delete(game.Status.Pods, name)
+
+			//set pod counts and conditions
+			tools.SetGameStatus(game)
+		}); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			log.Errorf("update games %s/%s status error %s", game.Name, game.Namespace, err.Error())
 			return err
 		}
+		c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonPodRemoved, "pod %s removed from status", name)
 
 		log.Tracef("update games %s/%s status", game.Name, game.Namespace)
 	}
-
 	return nil
 }
 
-func (c *Pod) deletePods(ctx context.Context, namespace, name string) error {
-	log.Tracef("pod delete %s/%s", namespace, name)
+//get the game of pod by owner reference, game name label or gameid label, nil if the game is gone
+func (c *Pod) getGame(pod *corev1.Pod) (*gamesv1.Game, error) {
+	lister := c.gameInformer.Lister().Games(pod.Namespace)
 
-	//delete pod
-	array := strings.Split(name, "-")
-	if len(array) > 1 {
-		//game not found
-		game, err := c.gamesclientset.KubegamesV1().Games(namespace).Get(ctx, array[0], v1.GetOptions{})
+	//owner reference
+	if ref := v1.GetControllerOf(pod); ref != nil {
+		if ref.Kind != "Game" {
+			return nil, nil
+		}
+		game, err := lister.Get(ref.Name)
 		if err != nil {
-			if errors.IsNotFound(err) == true {
-				return nil
+			if errors.IsNotFound(err) {
+				return nil, nil
 			}
-			log.Errorf("get game %s/%s error %s", namespace, array[0], err.Error())
-			return err
+			return nil, err
 		}
+		if game.UID != ref.UID {
+			return nil, nil
+		}
+		return game, nil
+	}
 
-		//check game is delete
-		if game.ObjectMeta.DeletionTimestamp.IsZero() {
-			//delete
-			delete(game.Status.Pods, name)
-
-			//set update
-			game.Status.UpdateAt = time.Now().String()
-
-			//update
-			if _, err := c.gamesclientset.KubegamesV1().Games(game.Namespace).UpdateStatus(ctx, game, v1.UpdateOptions{}); err != nil {
-				log.Errorf("update games %s/%s status error %s", game.Name, game.Namespace, err.Error())
-				return err
+	//game name label
+	if name, ok := pod.Labels[tools.LabelsGameName]; ok {
+		game, err := lister.Get(name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
 			}
+			return nil, err
+		}
+		return game, nil
+	}
 
-			log.Tracef("update games %s/%s status", game.Name, game.Namespace)
+	//gameid label of pods created before owner references
+	if gameid, ok := pod.Labels[tools.LabelsGameID]; ok {
+		objs, err := c.gameInformer.Informer().GetIndexer().ByIndex(tools.GameIDIndex, tools.IndexKey(pod.Namespace, gameid))
+		if err != nil {
+			return nil, err
+		}
+		if len(objs) > 0 {
+			return objs[0].(*gamesv1.Game), nil
 		}
 	}
-	return nil
+	return nil, nil
 }
@@ -0,0 +1,411 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	gamesclientset "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned"
+	gameinformers "github.com/kubegames/kubegames-operator/pkg/client/game/informers/externalversions/game/v1"
+	"github.com/kubegames/kubegames-operator/pkg/controller"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	podsv1 "k8s.io/client-go/informers/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Room is the room implementation for Room resources
+type Room struct {
+	// kubeclientset is a standard kubernetes clientset
+	kubeclientset kubernetes.Interface
+	// gamesclientset is a clientset for our own API group
+	gamesclientset gamesclientset.Interface
+	//room informer
+	informer gameinformers.RoomInformer
+	//game informer
+	gameInformer gameinformers.GameInformer
+	//pod informer
+	podInformer podsv1.PodInformer
+	//queue
+	workqueue workqueue.RateLimitingInterface
+	//progress of the workers
+	progress *controller.Progress
+	//event recorder
+	recorder record.EventRecorder
+	//serializes placement per game
+	locks *gameLocks
+	//pod of rooms placed but not seen placed in the cache yet, by room key
+	pendingMu sync.Mutex
+	pending   map[string]string
+}
+
+//mutex per game
+type gameLocks struct {
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+//lock key, returns the unlock func
+func (l *gameLocks) lock(key string) func() {
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = new(sync.Mutex)
+		l.locks[key] = lock
+	}
+	l.mu.Unlock()
+
+	lock.Lock()
+	return lock.Unlock
+}
+
+// returns a new room, the shared informers are started by the caller
+func NewRoom(kubeclientset kubernetes.Interface, gamesclientset gamesclientset.Interface, informers *controller.Informers) *Room {
+	room := &Room{
+		kubeclientset:  kubeclientset,
+		gamesclientset: gamesclientset,
+		workqueue:      controller.NewQueue(informers.QueueName("rooms")),
+		informer:       informers.Games.Kubegames().V1().Rooms(),
+		gameInformer:   informers.Games.Kubegames().V1().Games(),
+		podInformer:    informers.Kube.Core().V1().Pods(),
+		recorder:       controller.NewRecorder(kubeclientset),
+		locks:          &gameLocks{locks: make(map[string]*sync.Mutex)},
+		pending:        make(map[string]string),
+	}
+	room.progress = controller.NewProgress(room.workqueue)
+
+	//listen room change event
+	room.informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			key, err := cache.MetaNamespaceKeyFunc(obj)
+			if err != nil {
+				log.Errorf("add Func error %s", err.Error())
+				return
+			}
+			room.workqueue.Add(key)
+		},
+		UpdateFunc: func(old, new interface{}) {
+			oldroom := old.(*gamesv1.Room)
+			newroom := new.(*gamesv1.Room)
+			if oldroom.ResourceVersion != newroom.ResourceVersion {
+				key, err := cache.MetaNamespaceKeyFunc(new)
+				if err != nil {
+					log.Errorf("update Func error %s", err.Error())
+					return
+				}
+				room.workqueue.Add(key)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			//forget the pending placement
+			key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+			if err != nil {
+				log.Errorf("delete Func error %s", err.Error())
+				return
+			}
+			room.workqueue.Add(key)
+		},
+	})
+
+	//listen game change event, the pods of the game may have changed
+	room.gameInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldgame := old.(*gamesv1.Game)
+			newgame := new.(*gamesv1.Game)
+			if oldgame.ResourceVersion != newgame.ResourceVersion {
+				room.enqueueGameRooms(newgame)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			game, ok := obj.(*gamesv1.Game)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					log.Errorf("delete Func error unexpected object %#v", obj)
+					return
+				}
+				if game, ok = tombstone.Obj.(*gamesv1.Game); !ok {
+					log.Errorf("delete Func error unexpected tombstone object %#v", tombstone.Obj)
+					return
+				}
+			}
+			room.enqueueGameRooms(game)
+		},
+	})
+
+	//index games by gameid
+	if err := tools.AddGameIndexers(room.gameInformer.Informer()); err != nil {
+		panic(err)
+	}
+
+	return room
+}
+
+//enqueue all rooms of game
+func (c *Room) enqueueGameRooms(game *gamesv1.Game) {
+	rooms, err := c.informer.Lister().Rooms(game.Namespace).List(labels.Everything())
+	if err != nil {
+		log.Errorf("list rooms %s error %s", game.Namespace, err.Error())
+		return
+	}
+
+	for _, room := range rooms {
+		if room.Spec.GameID != game.Spec.GameID {
+			continue
+		}
+
+		key, err := cache.MetaNamespaceKeyFunc(room)
+		if err != nil {
+			log.Errorf("room key error %s", err.Error())
+			continue
+		}
+		c.workqueue.Add(key)
+	}
+}
+
+//run
+func (c *Room) Run(threadiness int, stopCh <-chan struct{}) {
+	defer runtime.HandleCrash()
+	defer c.workqueue.ShutDown()
+
+	if ok := cache.WaitForCacheSync(stopCh, c.HasSynced); !ok {
+		panic("failed to wait for caches to sync")
+	}
+
+	//keys queued while the caches synced are not a stall
+	c.progress.Observe()
+
+	for i := 0; i < threadiness; i++ {
+		go wait.Until(c.runWorker, time.Second, stopCh)
+	}
+
+	log.Infoln("room controller start")
+	<-stopCh
+	log.Infoln("room controller end")
+	return
+}
+
+//progress of the workers
+func (c *Room) Progress() *controller.Progress {
+	return c.progress
+}
+
+//informer caches synced
+func (c *Room) HasSynced() bool {
+	return c.informer.Informer().HasSynced() && c.gameInformer.Informer().HasSynced() && c.podInformer.Informer().HasSynced()
+}
+
+func (c *Room) runWorker() {
+	for controller.ProcessNextWorkItem("rooms", c.workqueue, c.syncHandler) {
+		c.progress.Observe()
+	}
+}
+
+// handler
+func (c *Room) syncHandler(ctx context.Context, key string) error {
+	// Convert the namespace/name string into a distinct namespace and name
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		log.Errorf("invalid resource key: %s", key)
+		return nil
+	}
+
+	// get room
+	room, err := c.informer.Lister().Rooms(namespace).Get(name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			log.Tracef("room delete %s/%s", namespace, name)
+			c.pendingMu.Lock()
+			delete(c.pending, key)
+			c.pendingMu.Unlock()
+			return nil
+		}
+
+		log.Errorf("failed to list rooms by: %s/%s", namespace, name)
+		return err
+	}
+
+	if room.ObjectMeta.DeletionTimestamp.IsZero() == false {
+		return nil
+	}
+
+	// get game
+	game, err := c.getGame(room)
+	if err != nil {
+		log.Errorf("get room %s/%s game error %s", namespace, name, err.Error())
+		return err
+	}
+
+	//room already placed
+	if len(room.Status.Pod) > 0 {
+		if game == nil || game.Status.Pods[room.Status.Pod] == nil {
+			log.Tracef("room %s/%s pod %s gone", namespace, name, room.Status.Pod)
+			if err := c.deleteRooms(ctx, room); err != nil {
+				return err
+			}
+			if game != nil {
+				c.recorder.Eventf(game, corev1.EventTypeNormal, controller.ReasonRoomDeleted, "deleted room %s, pod %s gone", room.Name, room.Status.Pod)
+			}
+			return nil
+		}
+		return nil
+	}
+
+	if game == nil {
+		return fmt.Errorf("room %s/%s game %s not found", namespace, name, room.Spec.GameID)
+	}
+
+	if err := c.placeRooms(ctx, room, game); err != nil {
+		log.Errorf("place room %s/%s error %s", namespace, name, err.Error())
+		return err
+	}
+
+	log.Tracef("sync room %s succcess", room.Name)
+	return nil
+}
+
+//get the game of room, nil if the game is gone
+func (c *Room) getGame(room *gamesv1.Room) (*gamesv1.Game, error) {
+	objs, err := c.gameInformer.Informer().GetIndexer().ByIndex(tools.GameIDIndex, tools.IndexKey(room.Namespace, room.Spec.GameID))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(objs) > 0 {
+		return objs[0].(*gamesv1.Game), nil
+	}
+	return nil, nil
+}
+
+//place room onto the game pod with the fewest rooms
+func (c *Room) placeRooms(ctx context.Context, room *gamesv1.Room, game *gamesv1.Game) error {
+	if game.ObjectMeta.DeletionTimestamp.IsZero() == false {
+		return fmt.Errorf("game %s/%s is deleting", game.Namespace, game.Name)
+	}
+
+	//workers place the rooms of a game one at a time
+	unlock := c.locks.lock(tools.IndexKey(room.Namespace, room.Spec.GameID))
+	defer unlock()
+
+	rooms, err := c.informer.Lister().Rooms(room.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	//count rooms of every pod, rooms placed by the last syncs may not be placed in the cache yet
+	counts := make(map[string]int)
+	unique := make(map[string]bool)
+	c.pendingMu.Lock()
+	for _, item := range rooms {
+		if item.Spec.GameID != room.Spec.GameID {
+			continue
+		}
+
+		key := tools.IndexKey(item.Namespace, item.Name)
+		podname := item.Status.Pod
+		if len(podname) > 0 {
+			delete(c.pending, key)
+		} else {
+			podname = c.pending[key]
+		}
+		if len(podname) <= 0 {
+			continue
+		}
+
+		counts[podname]++
+		if item.Spec.Unique {
+			unique[podname] = true
+		}
+	}
+	c.pendingMu.Unlock()
+
+	//status pods may be stale, the pod must exist and be ready
+	podname, ok := choosePod(game, room, counts, unique, func(name string) bool {
+		pod, err := c.podInformer.Lister().Pods(game.Namespace).Get(name)
+		return err == nil && tools.IsPodReady(pod)
+	})
+	if !ok {
+		c.recorder.Eventf(room, corev1.EventTypeWarning, controller.ReasonRoomUnplaceable, "game %s has no pod available", game.Name)
+		return fmt.Errorf("game %s/%s has no pod available", game.Namespace, game.Name)
+	}
+
+	pod := game.Status.Pods[podname]
+
+	newroom := room.DeepCopy()
+	newroom.Status.Game = game.Name
+	newroom.Status.Pod = pod.Name
+	newroom.Status.HostIP = pod.HostIP
+	newroom.Status.PodIP = pod.PodIP
+	newroom.Status.Port = pod.Port
+	newroom.Status.UpdateAt = time.Now().String()
+
+	//update
+	if _, err := c.gamesclientset.KubegamesV1().Rooms(room.Namespace).UpdateStatus(ctx, newroom, metav1.UpdateOptions{}); err != nil {
+		log.Errorf("update rooms %s/%s status error %s", room.Namespace, room.Name, err.Error())
+		return err
+	}
+
+	c.pendingMu.Lock()
+	c.pending[tools.IndexKey(room.Namespace, room.Name)] = pod.Name
+	c.pendingMu.Unlock()
+
+	c.recorder.Eventf(room, corev1.EventTypeNormal, controller.ReasonRoomPlaced, "placed on pod %s %s:%d", pod.Name, pod.PodIP, pod.Port)
+	log.Tracef("place room %s/%s on pod %s", room.Namespace, room.Name, pod.Name)
+	return nil
+}
+
+//ready pod of game with the fewest rooms, pods with a unique room and draining pods take no room,
+//unique rooms take empty pods only. ties go to the first pod by name
+func choosePod(game *gamesv1.Game, room *gamesv1.Room, counts map[string]int, unique map[string]bool, ready func(name string) bool) (string, bool) {
+	//pods sort by name
+	names := make([]string, 0, len(game.Status.Pods))
+	for name, pod := range game.Status.Pods {
+		if pod == nil || pod.Ready == false || pod.DrainStartTime != nil {
+			continue
+		}
+		if unique[name] || (room.Spec.Unique && counts[name] > 0) {
+			continue
+		}
+		if ready(name) == false {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if len(names) <= 0 {
+		return "", false
+	}
+
+	//pod with the fewest rooms
+	podname := names[0]
+	for _, name := range names {
+		if counts[name] < counts[podname] {
+			podname = name
+		}
+	}
+	return podname, true
+}
+
+//delete room
+func (c *Room) deleteRooms(ctx context.Context, room *gamesv1.Room) error {
+	if err := c.gamesclientset.KubegamesV1().Rooms(room.Namespace).Delete(ctx, room.Name, metav1.DeleteOptions{}); err != nil {
+		if errors.IsNotFound(err) == false {
+			log.Errorf("delete room %s/%s error %s", room.Namespace, room.Name, err.Error())
+			return err
+		}
+	}
+	return nil
+}
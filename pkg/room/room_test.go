@@ -0,0 +1,52 @@
+package room
+
+import (
+	"testing"
+
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestChoosePod(t *testing.T) {
+	now := metav1.Now()
+	game := &gamesv1.Game{
+		Status: gamesv1.GamesStatus{
+			Pods: map[string]*gamesv1.PodStatus{
+				"poker-0": {Name: "poker-0", Ready: true},
+				"poker-1": {Name: "poker-1", Ready: true},
+				"poker-2": {Name: "poker-2", Ready: false},
+				"poker-3": {Name: "poker-3", Ready: true, DrainStartTime: &now},
+			},
+		},
+	}
+	all := func(name string) bool { return true }
+
+	tests := []struct {
+		name   string
+		unique bool
+		counts map[string]int
+		locked map[string]bool
+		ready  func(name string) bool
+		pod    string
+		ok     bool
+	}{
+		{name: "first pod by name", ready: all, pod: "poker-0", ok: true},
+		{name: "fewest rooms", counts: map[string]int{"poker-0": 2, "poker-1": 1}, ready: all, pod: "poker-1", ok: true},
+		{name: "not ready and draining pods take no room", counts: map[string]int{"poker-0": 5, "poker-1": 5}, ready: all, pod: "poker-0", ok: true},
+		{name: "pod with a unique room", counts: map[string]int{"poker-0": 1}, locked: map[string]bool{"poker-0": true}, ready: all, pod: "poker-1", ok: true},
+		{name: "unique room takes an empty pod", unique: true, counts: map[string]int{"poker-0": 1}, ready: all, pod: "poker-1", ok: true},
+		{name: "no empty pod for a unique room", unique: true, counts: map[string]int{"poker-0": 1, "poker-1": 1}, ready: all, ok: false},
+		{name: "pod gone from the cache", ready: func(name string) bool { return name != "poker-0" }, pod: "poker-1", ok: true},
+		{name: "no pod", ready: func(name string) bool { return false }, ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			room := &gamesv1.Room{Spec: gamesv1.RoomSpec{GameID: "poker", Unique: test.unique}}
+			pod, ok := choosePod(game, room, test.counts, test.locked, test.ready)
+			if pod != test.pod || ok != test.ok {
+				t.Errorf("pod = %s, %v, want %s, %v", pod, ok, test.pod, test.ok)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/kubegames/kubegames-operator/internal/pkg/log"
 	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
@@ -12,12 +13,14 @@ import (
 	rs "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 const (
 	MountPath             = "/game/config"
 	MountConfigName       = "config"
 	LabelsGameID          = "gameid"
+	LabelsGameName        = "game-name"
 	LabelsProxy           = "proxy"
 	RunPort               = "RUN_PORT"
 	PodIp                 = "POD_IP"
@@ -25,6 +28,11 @@ const (
 	LabelsController      = "controller"
 	LabelsControllerValue = "kubegames"
 	LabelsPort            = "port"
+	LabelsRevision        = "revision"
+	AnnotationsConfigHash = "kubegames.com/config-hash"
+	AnnotationsDefaults   = "kubegames.com/game-defaults"
+	AnnotationsSidecar    = "kubegames.com/sidecar-profile"
+	AnnotationsInjected   = "kubegames.com/sidecar-injected"
 )
 
 //create configmap
@@ -37,6 +45,7 @@ func CreateConfigMap(game *gamesv1.Game) *coreV1.ConfigMap {
 				LabelsGameID:     game.Spec.GameID,
 				LabelsController: LabelsControllerValue,
 			},
+			OwnerReferences: []metav1.OwnerReference{GameOwnerReference(game)},
 		},
 		Data: map[string]string{
 			MountConfigName: game.Spec.Config,
@@ -110,10 +119,13 @@ func CreatePod(podname string, game *gamesv1.Game) *coreV1.Pod {
 				LabelsGameID:     game.Spec.GameID,
 				LabelsController: LabelsControllerValue,
 				LabelsPort:       fmt.Sprintf("%d", game.Spec.Port),
+				LabelsRevision:   Revision(game),
 			},
 			Annotations: map[string]string{
-				LabelsProxy: base64,
+				LabelsProxy:           base64,
+				AnnotationsConfigHash: ConfigHash(game),
 			},
+			OwnerReferences: []metav1.OwnerReference{GameOwnerReference(game)},
 		},
 		Spec: coreV1.PodSpec{
 			Containers: []coreV1.Container{
@@ -153,9 +165,77 @@ func CreatePod(podname string, game *gamesv1.Game) *coreV1.Pod {
 			Volumes: volumes,
 		},
 	}
+
+	//sidecar profile of the game pods
+	if profile, ok := game.Annotations[AnnotationsSidecar]; ok {
+		pod.Annotations[AnnotationsSidecar] = profile
+	}
+
+	//game name label, long game names are resolved by owner reference only
+	if len(validation.IsValidLabelValue(game.Name)) <= 0 {
+		pod.Labels[LabelsGameName] = game.Name
+	}
 	return &pod
 }
 
+//controller owner reference of game, dependents are garbage collected with the game
+func GameOwnerReference(game *gamesv1.Game) metav1.OwnerReference {
+	return *metav1.NewControllerRef(game, gamesv1.SchemeGroupVersion.WithKind("Game"))
+}
+
+//object is owned by game
+func IsOwnedBy(obj metav1.Object, game *gamesv1.Game) bool {
+	ref := metav1.GetControllerOf(obj)
+	return ref != nil && ref.UID == game.UID
+}
+
+//revision of the game pod template, pods are rolled when it changes. the config is compared
+//separately, so switching hot reload does not roll the pods
+func Revision(game *gamesv1.Game) string {
+	return Md5(fmt.Sprintf("%s\n%s", game.Spec.Image, strings.Join(game.Spec.Commonds, "\x00")))
+}
+
+//pod runs the current template and, without hot reload, the current config
+func IsPodCurrent(pod *coreV1.Pod, game *gamesv1.Game) bool {
+	revision, ok := pod.Labels[LabelsRevision]
+	if ok && revision != Revision(game) {
+		return false
+	}
+	if !ok && MatchesTemplate(pod, game) == false {
+		return false
+	}
+
+	//config changes roll the pods without hot reload
+	if game.Spec.DisableHotReload && pod.Annotations[AnnotationsConfigHash] != ConfigHash(game) {
+		return false
+	}
+	return true
+}
+
+//pod without revision label runs the current template of game
+func MatchesTemplate(pod *coreV1.Pod, game *gamesv1.Game) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != game.Spec.GameID {
+			continue
+		}
+		if container.Image != game.Spec.Image || len(container.Command) != len(game.Spec.Commonds) {
+			return false
+		}
+		for i := range container.Command {
+			if container.Command[i] != game.Spec.Commonds[i] {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+//hash of game config
+func ConfigHash(game *gamesv1.Game) string {
+	return Md5(game.Spec.Config)
+}
+
 func Md5(str string) string {
 	w := md5.New()
 	io.WriteString(w, str)
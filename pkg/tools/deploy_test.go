@@ -0,0 +1,89 @@
+package tools
+
+import (
+	"testing"
+
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testGame() *gamesv1.Game {
+	return &gamesv1.Game{
+		ObjectMeta: metav1.ObjectMeta{Name: "poker", Namespace: "default", UID: "poker-uid"},
+		Spec: gamesv1.GameSpec{
+			GameID:   "poker",
+			Image:    "poker:v1",
+			Port:     8080,
+			Commonds: []string{"./poker", "-v"},
+			Config:   "e30=",
+			Replicas: 4,
+		},
+	}
+}
+
+func TestRevision(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(game *gamesv1.Game)
+		changed bool
+	}{
+		{name: "unchanged", mutate: func(game *gamesv1.Game) {}, changed: false},
+		{name: "image", mutate: func(game *gamesv1.Game) { game.Spec.Image = "poker:v2" }, changed: true},
+		{name: "commonds", mutate: func(game *gamesv1.Game) { game.Spec.Commonds = []string{"./poker"} }, changed: true},
+		{name: "commonds split differently", mutate: func(game *gamesv1.Game) { game.Spec.Commonds = []string{"./poker -v"} }, changed: true},
+		{name: "config", mutate: func(game *gamesv1.Game) { game.Spec.Config = "e30K" }, changed: false},
+		{name: "hot reload disabled", mutate: func(game *gamesv1.Game) { game.Spec.DisableHotReload = true }, changed: false},
+		{name: "replicas", mutate: func(game *gamesv1.Game) { game.Spec.Replicas = 8 }, changed: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testGame()
+			revision := Revision(game)
+			test.mutate(game)
+			if changed := Revision(game) != revision; changed != test.changed {
+				t.Errorf("revision changed = %v, want %v", changed, test.changed)
+			}
+		})
+	}
+}
+
+func TestIsPodCurrent(t *testing.T) {
+	tests := []struct {
+		name      string
+		mutate    func(game *gamesv1.Game)
+		unlabel   bool
+		current   bool
+		unlabeled bool
+	}{
+		{name: "created by the current game", mutate: func(game *gamesv1.Game) {}, current: true},
+		{name: "image changed", mutate: func(game *gamesv1.Game) { game.Spec.Image = "poker:v2" }, current: false},
+		{name: "config changed with hot reload", mutate: func(game *gamesv1.Game) { game.Spec.Config = "e30K" }, current: true},
+		{
+			name: "config changed without hot reload",
+			mutate: func(game *gamesv1.Game) {
+				game.Spec.Config = "e30K"
+				game.Spec.DisableHotReload = true
+			},
+			current: false,
+		},
+		{name: "hot reload disabled", mutate: func(game *gamesv1.Game) { game.Spec.DisableHotReload = true }, current: true},
+		{name: "unlabelled with the current template", mutate: func(game *gamesv1.Game) {}, unlabel: true, current: true},
+		{name: "unlabelled with another image", mutate: func(game *gamesv1.Game) { game.Spec.Image = "poker:v2" }, unlabel: true, current: false},
+		{name: "unlabelled with other commonds", mutate: func(game *gamesv1.Game) { game.Spec.Commonds = nil }, unlabel: true, current: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testGame()
+			pod := CreatePod("poker-0", game)
+			if test.unlabel {
+				delete(pod.Labels, LabelsRevision)
+			}
+			test.mutate(game)
+			if current := IsPodCurrent(pod, game); current != test.current {
+				t.Errorf("current = %v, want %v", current, test.current)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package tools
+
+import (
+	"fmt"
+
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	//index games by the namespace/name of their status pods
+	GamePodIndex = "pod"
+	//index games by namespace/gameid
+	GameIDIndex = "gameid"
+)
+
+//index key of namespace and name
+func IndexKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+//index func of game status pods
+func GamePodIndexFunc(obj interface{}) ([]string, error) {
+	game, ok := obj.(*gamesv1.Game)
+	if !ok {
+		return nil, fmt.Errorf("expected game but got %T", obj)
+	}
+
+	keys := make([]string, 0, len(game.Status.Pods))
+	for name := range game.Status.Pods {
+		keys = append(keys, IndexKey(game.Namespace, name))
+	}
+	return keys, nil
+}
+
+//index func of game gameid
+func GameIDIndexFunc(obj interface{}) ([]string, error) {
+	game, ok := obj.(*gamesv1.Game)
+	if !ok {
+		return nil, fmt.Errorf("expected game but got %T", obj)
+	}
+	return []string{IndexKey(game.Namespace, game.Spec.GameID)}, nil
+}
+
+//add game indexers to the shared game informer, indexers already added are skipped
+func AddGameIndexers(informer cache.SharedIndexInformer) error {
+	indexers := cache.Indexers{}
+	existing := informer.GetIndexer().GetIndexers()
+	for name, f := range map[string]cache.IndexFunc{
+		GamePodIndex: GamePodIndexFunc,
+		GameIDIndex:  GameIDIndexFunc,
+	} {
+		if _, ok := existing[name]; !ok {
+			indexers[name] = f
+		}
+	}
+
+	if len(indexers) <= 0 {
+		return nil
+	}
+	return informer.AddIndexers(indexers)
+}
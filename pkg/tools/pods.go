@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var (
+	defaultMaxUnavailable = intstr.FromString("25%")
+	defaultMaxSurge       = intstr.FromString("25%")
+)
+
+//label selector of game pods
+func GameSelector(game *gamesv1.Game) labels.Selector {
+	return labels.SelectorFromSet(labels.Set{
+		LabelsGameID:     game.Spec.GameID,
+		LabelsController: LabelsControllerValue,
+	})
+}
+
+//pod is controlled by game, or has no controller and may be adopted by game. pods of another
+//game with the same gameid are never claimed
+func IsGamePod(pod *coreV1.Pod, game *gamesv1.Game) bool {
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		return ref.UID == game.UID
+	}
+
+	//deleted games adopt nothing
+	if game.ObjectMeta.DeletionTimestamp.IsZero() == false {
+		return false
+	}
+
+	//game name label of pods created by another game
+	if name, ok := pod.Labels[LabelsGameName]; ok {
+		return name == game.Name
+	}
+	return true
+}
+
+//pod is running and containers ready
+func IsPodReady(pod *coreV1.Pod) bool {
+	if pod.Status.Phase != coreV1.PodRunning || pod.ObjectMeta.DeletionTimestamp.IsZero() == false {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == coreV1.ContainersReady {
+			return condition.Status == coreV1.ConditionTrue
+		}
+	}
+	return false
+}
+
+//ordinal of game pod name, -1 if the name is not a game pod name
+func PodOrdinal(game *gamesv1.Game, podname string) int {
+	prefix := game.Spec.GameID + "-"
+	if strings.HasPrefix(podname, prefix) == false {
+		return -1
+	}
+	ordinal, err := strconv.Atoi(strings.TrimPrefix(podname, prefix))
+	if err != nil || ordinal < 0 {
+		return -1
+	}
+	return ordinal
+}
+
+//lowest n free pod names of game
+func NextPodNames(game *gamesv1.Game, pods []*coreV1.Pod, n int) []string {
+	used := make(map[int]bool, len(pods))
+	for _, pod := range pods {
+		used[PodOrdinal(game, pod.Name)] = true
+	}
+
+	names := make([]string, 0, n)
+	for ordinal := 0; len(names) < n; ordinal++ {
+		if used[ordinal] {
+			continue
+		}
+		names = append(names, fmt.Sprintf("%s-%d", game.Spec.GameID, ordinal))
+	}
+	return names
+}
+
+//max surge and max unavailable of game rolling update
+func RollingUpdateBounds(game *gamesv1.Game) (maxSurge int, maxUnavailable int, err error) {
+	surge, unavailable := &defaultMaxSurge, &defaultMaxUnavailable
+	if game.Spec.Strategy.MaxSurge != nil {
+		surge = game.Spec.Strategy.MaxSurge
+	}
+	if game.Spec.Strategy.MaxUnavailable != nil {
+		unavailable = game.Spec.Strategy.MaxUnavailable
+	}
+
+	if maxSurge, err = intstr.GetScaledValueFromIntOrPercent(surge, int(game.Spec.Replicas), true); err != nil {
+		return 0, 0, err
+	}
+	if maxUnavailable, err = intstr.GetScaledValueFromIntOrPercent(unavailable, int(game.Spec.Replicas), false); err != nil {
+		return 0, 0, err
+	}
+
+	//can not make progress
+	if maxSurge == 0 && maxUnavailable == 0 {
+		maxUnavailable = 1
+	}
+	return maxSurge, maxUnavailable, nil
+}
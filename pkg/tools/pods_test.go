@@ -0,0 +1,150 @@
+package tools
+
+import (
+	"strings"
+	"testing"
+
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestNextPodNames(t *testing.T) {
+	tests := []struct {
+		name  string
+		pods  []string
+		n     int
+		names []string
+	}{
+		{name: "no pods", pods: nil, n: 3, names: []string{"poker-0", "poker-1", "poker-2"}},
+		{name: "fill gaps first", pods: []string{"poker-0", "poker-2", "poker-4"}, n: 3, names: []string{"poker-1", "poker-3", "poker-5"}},
+		{name: "other pod names are ignored", pods: []string{"poker-x", "holdem-0", "poker-0"}, n: 1, names: []string{"poker-1"}},
+		{name: "none", pods: []string{"poker-0"}, n: 0, names: []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pods := make([]*coreV1.Pod, 0, len(test.pods))
+			for _, name := range test.pods {
+				pods = append(pods, &coreV1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name}})
+			}
+			names := NextPodNames(testGame(), pods, test.n)
+			if strings.Join(names, ",") != strings.Join(test.names, ",") {
+				t.Errorf("names = %v, want %v", names, test.names)
+			}
+		})
+	}
+}
+
+func TestRollingUpdateBounds(t *testing.T) {
+	intOrString := func(value intstr.IntOrString) *intstr.IntOrString {
+		return &value
+	}
+
+	tests := []struct {
+		name           string
+		replicas       uint32
+		strategy       gamesv1.GameUpdateStrategy
+		maxSurge       int
+		maxUnavailable int
+		err            bool
+	}{
+		{name: "defaults", replicas: 4, maxSurge: 1, maxUnavailable: 1},
+		{name: "surge rounds up and unavailable rounds down", replicas: 10, maxSurge: 3, maxUnavailable: 2},
+		{name: "one replica", replicas: 1, maxSurge: 1, maxUnavailable: 0},
+		{
+			name:     "absolute values",
+			replicas: 10,
+			strategy: gamesv1.GameUpdateStrategy{MaxSurge: intOrString(intstr.FromInt(0)), MaxUnavailable: intOrString(intstr.FromInt(3))},
+			maxSurge: 0, maxUnavailable: 3,
+		},
+		{
+			name:     "both zero can not make progress",
+			replicas: 10,
+			strategy: gamesv1.GameUpdateStrategy{MaxSurge: intOrString(intstr.FromInt(0)), MaxUnavailable: intOrString(intstr.FromString("0%"))},
+			maxSurge: 0, maxUnavailable: 1,
+		},
+		{
+			name:     "invalid percent",
+			replicas: 10,
+			strategy: gamesv1.GameUpdateStrategy{MaxSurge: intOrString(intstr.FromString("lots"))},
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			game := testGame()
+			game.Spec.Replicas = test.replicas
+			game.Spec.Strategy = test.strategy
+			maxSurge, maxUnavailable, err := RollingUpdateBounds(game)
+			if (err != nil) != test.err {
+				t.Fatalf("err = %v, want error %v", err, test.err)
+			}
+			if test.err {
+				return
+			}
+			if maxSurge != test.maxSurge || maxUnavailable != test.maxUnavailable {
+				t.Errorf("bounds = %d, %d, want %d, %d", maxSurge, maxUnavailable, test.maxSurge, test.maxUnavailable)
+			}
+		})
+	}
+}
+
+func TestIsGamePod(t *testing.T) {
+	game := testGame()
+	other := testGame()
+	other.Name, other.UID = "poker-copy", "poker-copy-uid"
+	now := metav1.Now()
+	deleting := testGame()
+	deleting.DeletionTimestamp = &now
+
+	tests := []struct {
+		name  string
+		pod   func() *coreV1.Pod
+		game  *gamesv1.Game
+		owned bool
+	}{
+		{name: "controlled", pod: func() *coreV1.Pod { return CreatePod("poker-0", game) }, game: game, owned: true},
+		{name: "controlled by another game", pod: func() *coreV1.Pod { return CreatePod("poker-0", other) }, game: game, owned: false},
+		{
+			name: "orphan",
+			pod: func() *coreV1.Pod {
+				pod := CreatePod("poker-0", game)
+				pod.OwnerReferences = nil
+				return pod
+			},
+			game:  game,
+			owned: true,
+		},
+		{
+			name: "orphan labelled for another game",
+			pod: func() *coreV1.Pod {
+				pod := CreatePod("poker-0", other)
+				pod.OwnerReferences = nil
+				return pod
+			},
+			game:  game,
+			owned: false,
+		},
+		{
+			name: "orphan of a deleted game",
+			pod: func() *coreV1.Pod {
+				pod := CreatePod("poker-0", game)
+				pod.OwnerReferences = nil
+				return pod
+			},
+			game:  deleting,
+			owned: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if owned := IsGamePod(test.pod(), test.game); owned != test.owned {
+				t.Errorf("owned = %v, want %v", owned, test.owned)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gamesv1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	gamesclientset "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+)
+
+//update game status through the status subresource, mutate is applied again
+//to the latest game when the write conflicts
+func UpdateGameStatus(ctx context.Context, client gamesclientset.Interface, game *gamesv1.Game, mutate func(game *gamesv1.Game)) error {
+	current := game
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		//get latest game
+		if current == nil {
+			latest, err := client.KubegamesV1().Games(game.Namespace).Get(ctx, game.Name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+			current = latest
+		}
+
+		newgame := current.DeepCopy()
+		mutate(newgame)
+
+		//nothing changed
+		if equality.Semantic.DeepEqual(current.Status, newgame.Status) {
+			return nil
+		}
+		current = nil
+
+		//set update
+		newgame.Status.UpdateAt = time.Now().String()
+
+		_, err := client.KubegamesV1().Games(newgame.Namespace).UpdateStatus(ctx, newgame, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+//set game pod counts, Available and Progressing conditions from status pods
+func SetGameStatus(game *gamesv1.Game) {
+	var replicas, ready, updated uint32
+	for _, pod := range game.Status.Pods {
+		if pod == nil {
+			continue
+		}
+		replicas++
+		if pod.Ready {
+			ready++
+			if pod.Revision == game.Status.UpdateRevision {
+				updated++
+			}
+		}
+	}
+
+	game.Status.Replicas = replicas
+	game.Status.ReadyReplicas = ready
+	game.Status.UpdatedReplicas = updated
+	game.Status.Selector = GameSelector(game).String()
+
+	//available
+	_, maxUnavailable, _ := RollingUpdateBounds(game)
+	if int(ready) >= int(game.Spec.Replicas)-maxUnavailable {
+		SetGameCondition(game, gamesv1.GameAvailable, metav1.ConditionTrue, "MinimumReplicasAvailable",
+			fmt.Sprintf("%d/%d pods ready", ready, game.Spec.Replicas))
+	} else {
+		SetGameCondition(game, gamesv1.GameAvailable, metav1.ConditionFalse, "MinimumReplicasUnavailable",
+			fmt.Sprintf("%d/%d pods ready", ready, game.Spec.Replicas))
+	}
+
+	//progressing
+	switch {
+	case updated < ready:
+		SetGameCondition(game, gamesv1.GameProgressing, metav1.ConditionTrue, "RollingUpdate",
+			fmt.Sprintf("%d/%d pods updated", updated, game.Spec.Replicas))
+	case replicas != game.Spec.Replicas || ready != game.Spec.Replicas:
+		SetGameCondition(game, gamesv1.GameProgressing, metav1.ConditionTrue, "Scaling",
+			fmt.Sprintf("%d/%d pods ready", ready, game.Spec.Replicas))
+	default:
+		SetGameCondition(game, gamesv1.GameProgressing, metav1.ConditionFalse, "RolloutComplete",
+			fmt.Sprintf("%d/%d pods updated", updated, game.Spec.Replicas))
+	}
+}
+
+//set game condition
+func SetGameCondition(game *gamesv1.Game, conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&game.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		ObservedGeneration: game.Generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kubegames/kubegames-operator/internal/pkg/log"
+	"github.com/kubegames/kubegames-operator/pkg/config"
+	"github.com/kubegames/kubegames-operator/pkg/convert"
+	"github.com/kubegames/kubegames-operator/pkg/scheme"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	"github.com/wI2L/jsondiff"
+	v1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+//mutating game pods, injects the sidecar profile
+func (w *Webhook) MutatingPods(ar v1.AdmissionReview) *v1.AdmissionResponse {
+	req := ar.Request
+	if req.Operation != v1.Create || req.Kind.Kind != "Pod" {
+		return &v1.AdmissionResponse{Allowed: true}
+	}
+
+	log.Tracef("Mutating %s Kind=%v, Namespace=%v Name=%v", req.Operation, req.Kind, req.Namespace, req.Name)
+
+	pod := new(corev1.Pod)
+	deserializer := scheme.Codecs.UniversalDeserializer()
+	if _, _, err := deserializer.Decode(req.Object.Raw, nil, pod); err != nil {
+		log.Errorln(err)
+		return convert.ToV1AdmissionResponse(err)
+	}
+
+	//not a game pod, or injected already
+	if pod.Labels[tools.LabelsController] != tools.LabelsControllerValue {
+		return &v1.AdmissionResponse{Allowed: true}
+	}
+	if _, ok := pod.Annotations[tools.AnnotationsInjected]; ok {
+		return &v1.AdmissionResponse{Allowed: true}
+	}
+
+	name, err := w.sidecarProfile(req.Namespace, pod)
+	if err != nil {
+		log.Errorf("get pod %s/%s sidecar profile error %s", req.Namespace, pod.Name, err.Error())
+		return convert.ToV1AdmissionResponse(err)
+	}
+	if len(name) <= 0 {
+		return &v1.AdmissionResponse{Allowed: true}
+	}
+
+	profile, ok := w.profiles[name]
+	if !ok {
+		err := fmt.Errorf("sidecar profile %s of pod %s is not configured !", name, pod.Name)
+		log.Errorln(err.Error())
+		return convert.ToV1AdmissionResponse(err)
+	}
+
+	return MutatingPod(pod, name, profile)
+}
+
+//profile of pod, the game annotation propagated to the pod first, then the namespace annotation and the default
+func (w *Webhook) sidecarProfile(namespace string, pod *corev1.Pod) (string, error) {
+	if name, ok := pod.Annotations[tools.AnnotationsSidecar]; ok {
+		return name, nil
+	}
+
+	name, ok, err := w.namespaceAnnotation(namespace, tools.AnnotationsSidecar)
+	if err != nil {
+		return "", err
+	}
+	if ok {
+		return name, nil
+	}
+	return w.defaultProfile, nil
+}
+
+func MutatingPod(pod *corev1.Pod, name string, profile config.SidecarProfile) *v1.AdmissionResponse {
+	//new pod
+	newpod := pod.DeepCopy()
+
+	if len(newpod.Annotations) <= 0 {
+		newpod.Annotations = make(map[string]string)
+	}
+
+	//mark injected
+	newpod.Annotations[tools.AnnotationsInjected] = name
+
+	//env and volume mounts of the game containers
+	for i := range newpod.Spec.Containers {
+		container := &newpod.Spec.Containers[i]
+		for _, env := range profile.Env {
+			if hasEnv(container.Env, env.Name) == false {
+				container.Env = append(container.Env, env)
+			}
+		}
+		for _, mount := range profile.VolumeMounts {
+			if hasVolumeMount(container.VolumeMounts, mount.MountPath) == false {
+				container.VolumeMounts = append(container.VolumeMounts, mount)
+			}
+		}
+	}
+
+	//sidecars
+	for _, container := range profile.Containers {
+		if hasContainer(newpod.Spec.Containers, container.Name) == false {
+			newpod.Spec.Containers = append(newpod.Spec.Containers, container)
+		}
+	}
+
+	//init containers
+	for _, container := range profile.InitContainers {
+		if hasContainer(newpod.Spec.InitContainers, container.Name) == false {
+			newpod.Spec.InitContainers = append(newpod.Spec.InitContainers, container)
+		}
+	}
+
+	//volumes
+	for _, volume := range profile.Volumes {
+		if hasVolume(newpod.Spec.Volumes, volume.Name) == false {
+			newpod.Spec.Volumes = append(newpod.Spec.Volumes, volume)
+		}
+	}
+
+	patch, err := jsondiff.Compare(pod, newpod)
+	if err != nil {
+		log.Errorf("patch Compare process error: %v", err.Error())
+		return convert.ToV1AdmissionResponse(err)
+	}
+
+	patchBytes, err := json.MarshalIndent(patch, "", "    ")
+	if err != nil {
+		log.Errorf("patch process error: %v", err.Error())
+		return convert.ToV1AdmissionResponse(err)
+	}
+
+	log.Infof("pod %s/%s sidecar profile %s patch=%v", pod.Namespace, pod.Name, name, string(patchBytes))
+
+	return &v1.AdmissionResponse{
+		Allowed: true,
+		Patch:   patchBytes,
+		PatchType: func() *v1.PatchType {
+			pt := v1.PatchTypeJSONPatch
+			return &pt
+		}(),
+	}
+}
+
+func hasContainer(containers []corev1.Container, name string) bool {
+	for _, container := range containers {
+		if container.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasEnv(envs []corev1.EnvVar, name string) bool {
+	for _, env := range envs {
+		if env.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolume(volumes []corev1.Volume, name string) bool {
+	for _, volume := range volumes {
+		if volume.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func hasVolumeMount(mounts []corev1.VolumeMount, path string) bool {
+	for _, mount := range mounts {
+		if mount.MountPath == path {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	gamev1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	//cpu limit bounds(1000 = 1cpu), 0 is unlimited
+	minCpu = 10
+	maxCpu = 64000
+	//memory limit bounds(1=1Mi), 0 is unlimited
+	minMemory = 4
+	maxMemory = 256 * 1024
+	//commonds bounds
+	maxCommonds      = 64
+	maxCommondLength = 4096
+	//image reference length
+	maxImageLength = 255
+)
+
+var (
+	//image reference grammar of github.com/distribution/reference
+	domainComponent = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+	domain          = domainComponent + `(?:\.` + domainComponent + `)*(?::[0-9]+)?`
+	pathComponent   = `[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*`
+	imageName       = `(?:` + domain + `/)?` + pathComponent + `(?:/` + pathComponent + `)*`
+	imageTag        = `[\w][\w.-]{0,127}`
+	imageDigest     = `[A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}`
+	imageReference  = regexp.MustCompile(`^` + imageName + `(?::` + imageTag + `)?(?:@` + imageDigest + `)?$`)
+)
+
+//validate game spec, all errors are returned
+func ValidateGameSpec(spec *gamev1.GameSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	//gameid names the pods, containers and config map
+	if len(spec.GameID) <= 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("gameID"), ""))
+	} else {
+		for _, msg := range validation.IsDNS1123Label(spec.GameID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("gameID"), spec.GameID, msg))
+		}
+	}
+
+	//image
+	switch {
+	case len(spec.Image) <= 0:
+		allErrs = append(allErrs, field.Required(fldPath.Child("image"), ""))
+	case len(spec.Image) > maxImageLength:
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("image"), spec.Image, maxImageLength))
+	case imageReference.MatchString(spec.Image) == false:
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("image"), spec.Image, "must be a valid image reference"))
+	}
+
+	//port
+	for _, msg := range validation.IsValidPortNum(int(spec.Port)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("port"), int(spec.Port), msg))
+	}
+
+	//cpu and memory limits
+	if spec.Cpu != 0 && (spec.Cpu < minCpu || spec.Cpu > maxCpu) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpu"), int(spec.Cpu), fmt.Sprintf("must be 0 or between %d and %d", minCpu, maxCpu)))
+	}
+	if spec.Memory != 0 && (spec.Memory < minMemory || spec.Memory > maxMemory) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), int(spec.Memory), fmt.Sprintf("must be 0 or between %d and %d", minMemory, maxMemory)))
+	}
+
+	//commonds
+	if len(spec.Commonds) > maxCommonds {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("commonds"), len(spec.Commonds), maxCommonds))
+	}
+	for i, commond := range spec.Commonds {
+		if len(commond) > maxCommondLength {
+			allErrs = append(allErrs, field.TooLong(fldPath.Child("commonds").Index(i), commond, maxCommondLength))
+		}
+	}
+
+	//config is base64 encoded json
+	allErrs = append(allErrs, validateConfig(spec.Config, fldPath.Child("config"))...)
+
+	//autoscaling
+	if autoscaling := spec.Autoscaling; autoscaling != nil {
+		autoscalingPath := fldPath.Child("autoscaling")
+		if autoscaling.MaxReplicas <= 0 {
+			allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("maxReplicas"), int(autoscaling.MaxReplicas), "must be greater than 0"))
+		}
+		if autoscaling.MinReplicas > autoscaling.MaxReplicas {
+			allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("minReplicas"), int(autoscaling.MinReplicas), "must not be greater than maxReplicas"))
+		}
+		if autoscaling.TargetUtilization > 100 {
+			allErrs = append(allErrs, field.Invalid(autoscalingPath.Child("targetUtilization"), int(autoscaling.TargetUtilization), "must be between 1 and 100"))
+		}
+	}
+
+	//drain
+	switch spec.Drain.Policy {
+	case "", gamev1.DrainPolicyWait, gamev1.DrainPolicyForce:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("drain", "policy"), spec.Drain.Policy, []string{string(gamev1.DrainPolicyWait), string(gamev1.DrainPolicyForce)}))
+	}
+
+	return allErrs
+}
+
+//config must decode, an empty config is allowed
+func validateConfig(config string, fldPath *field.Path) field.ErrorList {
+	if len(config) <= 0 {
+		return nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(config)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, config, fmt.Sprintf("must be base64 encoded: %s", err.Error()))}
+	}
+	if json.Valid(data) == false {
+		return field.ErrorList{field.Invalid(fldPath, config, "must be base64 encoded json")}
+	}
+	return nil
+}
+
+//validate new game
+func ValidateGame(game *gamev1.Game) field.ErrorList {
+	allErrs := ValidateGameSpec(&game.Spec, field.NewPath("spec"))
+
+	if tools.ContainsString(game.ObjectMeta.Finalizers, tools.Finalizer) == false {
+		allErrs = append(allErrs, field.Required(field.NewPath("metadata", "finalizers"), fmt.Sprintf("must contain %s", tools.Finalizer)))
+	}
+	return allErrs
+}
+
+//validate game update, gameID and port are immutable. stored games may predate the spec rules,
+//so only the errors of changed fields fail the update and deleted or unchanged specs are not validated
+func ValidateGameUpdate(game, old *gamev1.Game) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	//pods are looked up by the gameid label
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(game.Spec.GameID, old.Spec.GameID, field.NewPath("spec", "gameID"))...)
+
+	//pods route by the port
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(game.Spec.Port, old.Spec.Port, field.NewPath("spec", "port"))...)
+
+	//finalizer removal and status updates of existing games
+	if game.ObjectMeta.DeletionTimestamp.IsZero() == false || equality.Semantic.DeepEqual(game.Spec, old.Spec) {
+		return allErrs
+	}
+
+	oldErrs := ValidateGameSpec(&old.Spec, field.NewPath("spec"))
+	for _, err := range ValidateGameSpec(&game.Spec, field.NewPath("spec")) {
+		if hasError(oldErrs, err) == false {
+			allErrs = append(allErrs, err)
+		}
+	}
+	return allErrs
+}
+
+//errs has the same error on the same value
+func hasError(errs field.ErrorList, err *field.Error) bool {
+	for _, item := range errs {
+		if item.Type == err.Type && item.Field == err.Field && equality.Semantic.DeepEqual(item.BadValue, err.BadValue) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	gamev1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+func validSpec() gamev1.GameSpec {
+	return gamev1.GameSpec{
+		GameID:   "poker",
+		Image:    "registry.example.com/games/poker:v1",
+		Port:     8080,
+		Config:   base64.StdEncoding.EncodeToString([]byte(`{"seats":6}`)),
+		Replicas: 1,
+	}
+}
+
+//fields of errs
+func errorFields(errs field.ErrorList) []string {
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
+
+func TestValidateGameSpec(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(spec *gamev1.GameSpec)
+		fields []string
+	}{
+		{
+			name:   "valid",
+			mutate: func(spec *gamev1.GameSpec) {},
+			fields: []string{},
+		},
+		{
+			name: "empty config and unlimited resources",
+			mutate: func(spec *gamev1.GameSpec) {
+				spec.Config = ""
+				spec.Cpu, spec.Memory = 0, 0
+			},
+			fields: []string{},
+		},
+		{
+			name: "all errors are aggregated",
+			mutate: func(spec *gamev1.GameSpec) {
+				spec.GameID = "Poker_1"
+				spec.Image = "Registry/Poker:v1"
+				spec.Port = 0
+				spec.Cpu = 1
+				spec.Memory = 1
+				spec.Config = "not base64"
+				spec.Drain.Policy = "Later"
+			},
+			fields: []string{"spec.gameID", "spec.image", "spec.port", "spec.cpu", "spec.memory", "spec.config", "spec.drain.policy"},
+		},
+		{
+			name: "required fields",
+			mutate: func(spec *gamev1.GameSpec) {
+				spec.GameID = ""
+				spec.Image = ""
+			},
+			fields: []string{"spec.gameID", "spec.image"},
+		},
+		{
+			name: "config is not json",
+			mutate: func(spec *gamev1.GameSpec) {
+				spec.Config = base64.StdEncoding.EncodeToString([]byte("seats=6"))
+			},
+			fields: []string{"spec.config"},
+		},
+		{
+			name: "commonds",
+			mutate: func(spec *gamev1.GameSpec) {
+				spec.Commonds = []string{"./poker", strings.Repeat("a", maxCommondLength+1)}
+			},
+			fields: []string{"spec.commonds[1]"},
+		},
+		{
+			name: "autoscaling",
+			mutate: func(spec *gamev1.GameSpec) {
+				spec.Autoscaling = &gamev1.GameAutoscaling{MinReplicas: 1, TargetUtilization: 101}
+			},
+			fields: []string{"spec.autoscaling.maxReplicas", "spec.autoscaling.minReplicas", "spec.autoscaling.targetUtilization"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec := validSpec()
+			test.mutate(&spec)
+			fields := errorFields(ValidateGameSpec(&spec, field.NewPath("spec")))
+			if strings.Join(fields, ",") != strings.Join(test.fields, ",") {
+				t.Errorf("error fields = %v, want %v", fields, test.fields)
+			}
+		})
+	}
+}
+
+func TestValidateGameUpdate(t *testing.T) {
+	tests := []struct {
+		name   string
+		old    func(game *gamev1.Game)
+		mutate func(game *gamev1.Game)
+		fields []string
+	}{
+		{
+			name:   "unchanged",
+			old:    func(game *gamev1.Game) {},
+			mutate: func(game *gamev1.Game) {},
+			fields: []string{},
+		},
+		{
+			name:   "invalid change",
+			old:    func(game *gamev1.Game) {},
+			mutate: func(game *gamev1.Game) { game.Spec.Cpu = 1 },
+			fields: []string{"spec.cpu"},
+		},
+		{
+			name:   "immutable fields",
+			old:    func(game *gamev1.Game) {},
+			mutate: func(game *gamev1.Game) { game.Spec.GameID, game.Spec.Port = "holdem", 9090 },
+			fields: []string{"spec.gameID", "spec.port"},
+		},
+		{
+			name:   "stored invalid spec is not validated again",
+			old:    func(game *gamev1.Game) { game.Spec.Image = "Registry/Poker:v1" },
+			mutate: func(game *gamev1.Game) {},
+			fields: []string{},
+		},
+		{
+			name:   "only changed fields are validated",
+			old:    func(game *gamev1.Game) { game.Spec.Image = "Registry/Poker:v1" },
+			mutate: func(game *gamev1.Game) { game.Spec.Replicas, game.Spec.Memory = 3, 1 },
+			fields: []string{"spec.memory"},
+		},
+		{
+			name:   "changed invalid field",
+			old:    func(game *gamev1.Game) { game.Spec.Image = "Registry/Poker:v1" },
+			mutate: func(game *gamev1.Game) { game.Spec.Image = "Registry/Poker:v2" },
+			fields: []string{"spec.image"},
+		},
+		{
+			name: "deleted game",
+			old:  func(game *gamev1.Game) { game.Spec.Cpu = 1 },
+			mutate: func(game *gamev1.Game) {
+				now := metav1.Now()
+				game.DeletionTimestamp = &now
+				game.Finalizers = nil
+				game.Spec.Cpu = 2
+			},
+			fields: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			old := &gamev1.Game{ObjectMeta: metav1.ObjectMeta{Name: "poker", Namespace: "default"}, Spec: validSpec()}
+			test.old(old)
+			game := old.DeepCopy()
+			test.mutate(game)
+			fields := errorFields(ValidateGameUpdate(game, old))
+			if strings.Join(fields, ",") != strings.Join(test.fields, ",") {
+				t.Errorf("error fields = %v, want %v", fields, test.fields)
+			}
+		})
+	}
+}
@@ -1,22 +1,100 @@
 package webhook
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 
 	"github.com/kubegames/kubegames-operator/internal/pkg/log"
 	gamev1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	gamesclientset "github.com/kubegames/kubegames-operator/pkg/client/game/clientset/versioned"
+	"github.com/kubegames/kubegames-operator/pkg/config"
 	"github.com/kubegames/kubegames-operator/pkg/convert"
 	"github.com/kubegames/kubegames-operator/pkg/scheme"
 	"github.com/kubegames/kubegames-operator/pkg/tools"
 	"github.com/wI2L/jsondiff"
 	v1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
 )
 
+type (
+	//admission webhook of games
+	Webhook struct {
+		// kubeclientset is a standard kubernetes clientset
+		kubeclientset kubernetes.Interface
+		// gamesclientset is a clientset for our own API group
+		gamesclientset gamesclientset.Interface
+		//operator wide defaults of game fields
+		defaults config.GameDefaults
+		//sidecar profiles injected into game pods
+		profiles map[string]config.SidecarProfile
+		//profile of game pods without a profile annotation
+		defaultProfile string
+		//user name of the operator, the only user allowed to remove the finalizer
+		operatorUsername string
+	}
+
+	Option struct {
+		f func(*Webhook)
+	}
+)
+
+//set kube clientset, used to look up namespace defaults
+func KubeClientset(kubeclientset kubernetes.Interface) Option {
+	return Option{func(w *Webhook) {
+		w.kubeclientset = kubeclientset
+	}}
+}
+
+//set operator wide defaults of game fields
+func GameDefaults(defaults config.GameDefaults) Option {
+	return Option{func(w *Webhook) {
+		w.defaults = defaults
+	}}
+}
+
+//set sidecar profiles and the default profile of game pods
+func SidecarProfiles(profiles map[string]config.SidecarProfile, defaultProfile string) Option {
+	return Option{func(w *Webhook) {
+		w.profiles = profiles
+		w.defaultProfile = defaultProfile
+	}}
+}
+
+//set games clientset, used to look up other games
+func GamesClientset(gamesclientset gamesclientset.Interface) Option {
+	return Option{func(w *Webhook) {
+		w.gamesclientset = gamesclientset
+	}}
+}
+
+//set operator user name, anyone may remove the finalizer if empty
+func OperatorUsername(username string) Option {
+	return Option{func(w *Webhook) {
+		w.operatorUsername = username
+	}}
+}
+
+//new webhook
+func NewWebhook(options ...Option) *Webhook {
+	//init
+	w := new(Webhook)
+
+	//option
+	for _, option := range options {
+		option.f(w)
+	}
+	return w
+}
+
 // validate
-func Validating(ar v1.AdmissionReview) *v1.AdmissionResponse {
+func (w *Webhook) Validating(ar v1.AdmissionReview) *v1.AdmissionResponse {
 	req := ar.Request
-	if req.Operation != "CREATE" {
+	if req.Operation != v1.Create && req.Operation != v1.Update {
 		return &v1.AdmissionResponse{Allowed: true}
 	}
 
@@ -30,38 +108,113 @@ func Validating(ar v1.AdmissionReview) *v1.AdmissionResponse {
 			log.Errorln(err)
 			return convert.ToV1AdmissionResponse(err)
 		}
-		return ValidatingGame(game)
+
+		//update
+		if req.Operation == v1.Update {
+			old := new(gamev1.Game)
+			if _, _, err := deserializer.Decode(req.OldObject.Raw, nil, old); err != nil {
+				log.Errorln(err)
+				return convert.ToV1AdmissionResponse(err)
+			}
+			return w.validatingGameUpdate(req.UserInfo, old, game)
+		}
+
+		allErrs := ValidateGame(game)
+		dupErrs, err := w.validatingGameID(req.Namespace, game)
+		if err != nil {
+			return convert.ToV1AdmissionResponse(err)
+		}
+		return invalidResponse(game, append(allErrs, dupErrs...))
 	}
 
 	return &v1.AdmissionResponse{Allowed: true}
 }
 
-func ValidatingGame(game *gamev1.Game) *v1.AdmissionResponse {
-	if len(game.Spec.GameID) <= 0 {
-		err := fmt.Errorf("game.spec.gameID is null !")
-		log.Errorln()
-		return convert.ToV1AdmissionResponse(err)
+//only the operator removes the finalizer, anyone once the deleted game has no pods left
+func (w *Webhook) validatingGameUpdate(user authenticationv1.UserInfo, old, game *gamev1.Game) *v1.AdmissionResponse {
+	allErrs := ValidateGameUpdate(game, old)
+
+	//finalizer removed
+	removed := tools.ContainsString(old.ObjectMeta.Finalizers, tools.Finalizer) && tools.ContainsString(game.ObjectMeta.Finalizers, tools.Finalizer) == false
+	if removed && len(w.operatorUsername) > 0 && user.Username != w.operatorUsername {
+		gone, err := w.gamePodsGone(old)
+		if err != nil {
+			return convert.ToV1AdmissionResponse(err)
+		}
+		if gone {
+			log.Infof("user %s removes deleted game %s/%s finalizer", user.Username, game.Namespace, game.Name)
+			return invalidResponse(game, allErrs)
+		}
+
+		log.Errorf("user %s removes game %s/%s finalizer", user.Username, game.Namespace, game.Name)
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("metadata", "finalizers"), fmt.Sprintf("%s can only be removed by %s", tools.Finalizer, w.operatorUsername)))
 	}
 
-	if len(game.Spec.Image) <= 0 {
-		err := fmt.Errorf("game.spec.image is null !")
-		log.Errorln(err.Error())
-		return convert.ToV1AdmissionResponse(err)
+	return invalidResponse(game, allErrs)
+}
+
+//game is deleted and no pod of it is left
+func (w *Webhook) gamePodsGone(game *gamev1.Game) (bool, error) {
+	if game.ObjectMeta.DeletionTimestamp.IsZero() {
+		return false, nil
 	}
 
-	if tools.ContainsString(game.ObjectMeta.Finalizers, tools.Finalizer) == false {
-		err := fmt.Errorf("game.objectmeta.finalizers is null !")
-		log.Errorln(err.Error())
-		return convert.ToV1AdmissionResponse(err)
+	//pods in status without clientset
+	if w.kubeclientset == nil {
+		return len(game.Status.Pods) <= 0, nil
+	}
+
+	pods, err := w.kubeclientset.CoreV1().Pods(game.Namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: tools.GameSelector(game).String(),
+	})
+	if err != nil {
+		log.Errorf("list game %s/%s pods error %s", game.Namespace, game.Name, err.Error())
+		return false, err
+	}
+	for i := range pods.Items {
+		if tools.IsGamePod(&pods.Items[i], game) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+//gameid names the pods and config map, it must be unique in namespace
+func (w *Webhook) validatingGameID(namespace string, game *gamev1.Game) (field.ErrorList, error) {
+	if w.gamesclientset == nil {
+		return nil, nil
+	}
+
+	games, err := w.gamesclientset.KubegamesV1().Games(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		log.Errorf("list games %s error %s", namespace, err.Error())
+		return nil, err
 	}
 
-	reviewResponse := v1.AdmissionResponse{}
-	reviewResponse.Allowed = true
-	return &reviewResponse
+	for _, item := range games.Items {
+		if item.Name != game.Name && item.Spec.GameID == game.Spec.GameID {
+			log.Errorf("game.spec.gameID %s is used by game %s", game.Spec.GameID, item.Name)
+			return field.ErrorList{field.Duplicate(field.NewPath("spec", "gameID"), game.Spec.GameID)}, nil
+		}
+	}
+	return nil, nil
+}
+
+//all field errors at once in an invalid status with causes
+func invalidResponse(game *gamev1.Game, allErrs field.ErrorList) *v1.AdmissionResponse {
+	if len(allErrs) <= 0 {
+		reviewResponse := v1.AdmissionResponse{}
+		reviewResponse.Allowed = true
+		return &reviewResponse
+	}
+
+	err := errors.NewInvalid(gamev1.Kind("Game"), game.Name, allErrs)
+	log.Errorln(err.Error())
+	return convert.ToV1AdmissionResponse(err)
 }
 
 //mutating
-func Mutating(ar v1.AdmissionReview) *v1.AdmissionResponse {
+func (w *Webhook) Mutating(ar v1.AdmissionReview) *v1.AdmissionResponse {
 	req := ar.Request
 	if req.Operation != "CREATE" {
 		return &v1.AdmissionResponse{Allowed: true}
@@ -77,13 +230,77 @@ func Mutating(ar v1.AdmissionReview) *v1.AdmissionResponse {
 			log.Errorln(err)
 			return convert.ToV1AdmissionResponse(err)
 		}
-		return MutatingGame(game)
+
+		//fields set by the user are never defaulted
+		set, err := specFields(req.Object.Raw)
+		if err != nil {
+			log.Errorln(err)
+			return convert.ToV1AdmissionResponse(err)
+		}
+
+		defaults, err := w.gameDefaults(req.Namespace)
+		if err != nil {
+			log.Errorf("get namespace %s game defaults error %s", req.Namespace, err.Error())
+			return convert.ToV1AdmissionResponse(err)
+		}
+		return MutatingGame(game, set, defaults)
 	}
 
 	return &v1.AdmissionResponse{Allowed: true}
 }
 
-func MutatingGame(game *gamev1.Game) *v1.AdmissionResponse {
+//annotation of namespace, false if the namespace or annotation does not exist
+func (w *Webhook) namespaceAnnotation(namespace string, key string) (string, bool, error) {
+	if w.kubeclientset == nil {
+		return "", false, nil
+	}
+
+	ns, err := w.kubeclientset.CoreV1().Namespaces().Get(context.Background(), namespace, metav1.GetOptions{})
+	if err != nil {
+		//namespace is created by the operator
+		if errors.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+
+	value, ok := ns.Annotations[key]
+	return value, ok, nil
+}
+
+//operator defaults overridden by the namespace annotation
+func (w *Webhook) gameDefaults(namespace string) (config.GameDefaults, error) {
+	defaults := w.defaults
+
+	value, ok, err := w.namespaceAnnotation(namespace, tools.AnnotationsDefaults)
+	if err != nil || !ok {
+		return defaults, err
+	}
+
+	override := config.GameDefaults{}
+	if err := json.Unmarshal([]byte(value), &override); err != nil {
+		return defaults, fmt.Errorf("namespace %s annotation %s is invalid: %s", namespace, tools.AnnotationsDefaults, err.Error())
+	}
+	return defaults.Merge(override), nil
+}
+
+//spec fields present in the raw game
+func specFields(raw []byte) (map[string]bool, error) {
+	object := struct {
+		Spec map[string]json.RawMessage `json:"spec"`
+	}{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]bool, len(object.Spec))
+	for name := range object.Spec {
+		set[name] = true
+	}
+	return set, nil
+}
+
+func MutatingGame(game *gamev1.Game, set map[string]bool, defaults config.GameDefaults) *v1.AdmissionResponse {
 	//new game
 	newgame := game.DeepCopy()
 
@@ -95,8 +312,13 @@ func MutatingGame(game *gamev1.Game) *v1.AdmissionResponse {
 	newgame.Labels[tools.LabelsGameID] = newgame.Spec.GameID
 	newgame.Labels[tools.LabelsController] = tools.LabelsControllerValue
 
-	//add finalizers
-	newgame.ObjectMeta.Finalizers = append(newgame.ObjectMeta.Finalizers, tools.Finalizer)
+	//add finalizers once
+	if tools.ContainsString(newgame.ObjectMeta.Finalizers, tools.Finalizer) == false {
+		newgame.ObjectMeta.Finalizers = append(newgame.ObjectMeta.Finalizers, tools.Finalizer)
+	}
+
+	//default fields
+	warnings := defaultGame(newgame, set, defaults)
 
 	patch, err := jsondiff.Compare(game, newgame)
 	if err != nil {
@@ -104,6 +326,11 @@ func MutatingGame(game *gamev1.Game) *v1.AdmissionResponse {
 		return convert.ToV1AdmissionResponse(err)
 	}
 
+	//nothing to change
+	if len(patch) <= 0 {
+		return &v1.AdmissionResponse{Allowed: true, Warnings: warnings}
+	}
+
 	patchBytes, err := json.MarshalIndent(patch, "", "    ")
 	if err != nil {
 		log.Errorf("patch process error: %v", err.Error())
@@ -119,5 +346,32 @@ func MutatingGame(game *gamev1.Game) *v1.AdmissionResponse {
 			pt := v1.PatchTypeJSONPatch
 			return &pt
 		}(),
+		Warnings: warnings,
+	}
+}
+
+//fill the fields not set with defaults, returns a warning for each defaulted field
+func defaultGame(game *gamev1.Game, set map[string]bool, defaults config.GameDefaults) []string {
+	warnings := make([]string, 0)
+
+	if set["port"] == false && defaults.Port > 0 {
+		game.Spec.Port = defaults.Port
+		warnings = append(warnings, fmt.Sprintf("spec.port defaulted to %d", defaults.Port))
+	}
+
+	if set["cpu"] == false && defaults.Cpu > 0 {
+		game.Spec.Cpu = defaults.Cpu
+		warnings = append(warnings, fmt.Sprintf("spec.cpu defaulted to %d", defaults.Cpu))
+	}
+
+	if set["memory"] == false && defaults.Memory > 0 {
+		game.Spec.Memory = defaults.Memory
+		warnings = append(warnings, fmt.Sprintf("spec.memory defaulted to %d", defaults.Memory))
+	}
+
+	if set["replicas"] == false && defaults.Replicas > 0 {
+		game.Spec.Replicas = defaults.Replicas
+		warnings = append(warnings, fmt.Sprintf("spec.replicas defaulted to %d", defaults.Replicas))
 	}
+	return warnings
 }
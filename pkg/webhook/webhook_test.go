@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+
+	gamev1 "github.com/kubegames/kubegames-operator/pkg/apis/game/v1"
+	"github.com/kubegames/kubegames-operator/pkg/config"
+	"github.com/kubegames/kubegames-operator/pkg/tools"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMutatingGame(t *testing.T) {
+	defaults := config.GameDefaults{Port: 8080, Cpu: 500, Memory: 256, Replicas: 2}
+	labels := map[string]string{tools.LabelsGameID: "poker", tools.LabelsController: tools.LabelsControllerValue}
+	allSet := map[string]bool{"gameID": true, "image": true, "port": true, "cpu": true, "memory": true, "replicas": true}
+
+	tests := []struct {
+		name     string
+		game     *gamev1.Game
+		set      map[string]bool
+		paths    []string
+		warnings int
+	}{
+		{
+			name: "new game",
+			game: &gamev1.Game{
+				ObjectMeta: metav1.ObjectMeta{Name: "poker", Namespace: "default"},
+				Spec:       gamev1.GameSpec{GameID: "poker", Image: "poker:v1"},
+			},
+			set:      map[string]bool{"gameID": true, "image": true},
+			paths:    []string{"/metadata/labels", "/metadata/finalizers", "/spec/cpu", "/spec/memory", "/spec/port", "/spec/replicas"},
+			warnings: 4,
+		},
+		{
+			name: "user set fields are not defaulted",
+			game: &gamev1.Game{
+				ObjectMeta: metav1.ObjectMeta{Name: "poker", Namespace: "default"},
+				Spec:       gamev1.GameSpec{GameID: "poker", Image: "poker:v1", Port: 9090, Replicas: 0},
+			},
+			set:      map[string]bool{"gameID": true, "image": true, "port": true, "replicas": true},
+			paths:    []string{"/metadata/labels", "/metadata/finalizers", "/spec/cpu", "/spec/memory"},
+			warnings: 2,
+		},
+		{
+			name: "finalizer added once",
+			game: &gamev1.Game{
+				ObjectMeta: metav1.ObjectMeta{Name: "poker", Namespace: "default", Finalizers: []string{tools.Finalizer}},
+				Spec:       gamev1.GameSpec{GameID: "poker", Image: "poker:v1", Port: 8080, Cpu: 500, Memory: 256, Replicas: 2},
+			},
+			set:      allSet,
+			paths:    []string{"/metadata/labels"},
+			warnings: 0,
+		},
+		{
+			name: "defaulted and finalized",
+			game: &gamev1.Game{
+				ObjectMeta: metav1.ObjectMeta{Name: "poker", Namespace: "default", Labels: labels, Finalizers: []string{tools.Finalizer}},
+				Spec:       gamev1.GameSpec{GameID: "poker", Image: "poker:v1", Port: 8080, Cpu: 500, Memory: 256, Replicas: 2},
+			},
+			set:      allSet,
+			paths:    []string{},
+			warnings: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := MutatingGame(test.game, test.set, defaults)
+			if resp.Allowed == false {
+				t.Fatalf("not allowed: %v", resp.Result)
+			}
+			if len(test.paths) <= 0 && (len(resp.Patch) > 0 || resp.PatchType != nil) {
+				t.Errorf("patch = %s, want none", string(resp.Patch))
+			}
+			if len(resp.Warnings) != test.warnings {
+				t.Errorf("warnings = %v, want %d", resp.Warnings, test.warnings)
+			}
+
+			ops := make([]struct {
+				Path string `json:"path"`
+			}, 0)
+			if len(resp.Patch) > 0 {
+				if err := json.Unmarshal(resp.Patch, &ops); err != nil {
+					t.Fatalf("invalid patch %s: %s", string(resp.Patch), err.Error())
+				}
+			}
+			paths := make([]string, 0, len(ops))
+			for _, op := range ops {
+				paths = append(paths, op.Path)
+			}
+			sort.Strings(paths)
+			sort.Strings(test.paths)
+			if strings.Join(paths, ",") != strings.Join(test.paths, ",") {
+				t.Errorf("patch paths = %v, want %v", paths, test.paths)
+			}
+		})
+	}
+}